@@ -0,0 +1,207 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	osv1 "github.com/openshift/api/operator/v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apis"
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/components"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/network"
+)
+
+// cna-render renders objects of components requested by a NetworkAddonsConfig the same way the
+// operator does, without a cluster. Rendered objects are printed to stdout as a multi-document YAML
+func main() {
+	configFile := flag.String("config", "-", "NetworkAddonsConfig manifest to render, '-' reads it from stdin")
+	manifestDir := flag.String("manifest-dir", "", "Directory with templates of operand manifests, overriding those compiled into the binary")
+	namespace := flag.String("namespace", components.Namespace, "Namespace the operator and its components are deployed to")
+	openShift4 := flag.Bool("openshift4", false, "Render for OpenShift 4 cluster")
+	sccAvailable := flag.Bool("scc-available", false, "Render SecurityContextConstraints, available on OpenShift clusters")
+	monitoringAvailable := flag.Bool("monitoring-available", false, "Allow rendering of ServiceMonitor and PrometheusRule, available on clusters with Prometheus operator")
+	generateKubeMacPoolRange := flag.Bool("generate-kubemacpool-range", false, "Generate a random KubeMacPool range when it is not set in the config, rendering is not reproducible then")
+	openShiftNetworkConfigFile := flag.String("openshift-network-config", "", "OpenShift Network operator config, used to find out whether Multus is deployed by OpenShift")
+	multusImage := flag.String("multus-image", components.MultusImageDefault, "The multus image managed by CNA")
+	linuxBridgeCniImage := flag.String("linux-bridge-cni-image", components.LinuxBridgeCniImageDefault, "The linux bridge cni image managed by CNA")
+	linuxBridgeMarkerImage := flag.String("linux-bridge-marker-image", components.LinuxBridgeMarkerImageDefault, "The linux bridge marker image managed by CNA")
+	kubeMacPoolImage := flag.String("kubemacpool-image", components.KubeMacPoolImageDefault, "The kubemacpool-image managed by CNA")
+	nmStateHandlerImage := flag.String("nm-state-handler-image", components.NMStateHandlerImageDefault, "The nmstate handler image managed by CNA")
+	ovsCniImage := flag.String("ovs-cni-image", components.OvsCniImageDefault, "The ovs cni image managed by CNA")
+	ovsMarkerImage := flag.String("ovs-marker-image", components.OvsMarkerImageDefault, "The ovs marker image managed by CNA")
+	architecturesDefault := strings.Join(components.ArchitecturesDefault, ",")
+	multusArchitectures := flag.String("multus-image-architectures", architecturesDefault, "Comma separated list of architectures supported by the multus image")
+	linuxBridgeCniArchitectures := flag.String("linux-bridge-cni-image-architectures", architecturesDefault, "Comma separated list of architectures supported by the linux bridge cni image")
+	linuxBridgeMarkerArchitectures := flag.String("linux-bridge-marker-image-architectures", architecturesDefault, "Comma separated list of architectures supported by the linux bridge marker image")
+	kubeMacPoolArchitectures := flag.String("kubemacpool-image-architectures", architecturesDefault, "Comma separated list of architectures supported by the kubemacpool image")
+	nmStateHandlerArchitectures := flag.String("nm-state-handler-image-architectures", architecturesDefault, "Comma separated list of architectures supported by the nmstate handler image")
+	ovsCniArchitectures := flag.String("ovs-cni-image-architectures", architecturesDefault, "Comma separated list of architectures supported by the ovs cni image")
+	ovsMarkerArchitectures := flag.String("ovs-marker-image-architectures", architecturesDefault, "Comma separated list of architectures supported by the ovs marker image")
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	pflag.Parse()
+
+	addonsImages := &components.AddonsImages{
+		Multus:                         *multusImage,
+		LinuxBridgeCni:                 *linuxBridgeCniImage,
+		LinuxBridgeMarker:              *linuxBridgeMarkerImage,
+		KubeMacPool:                    *kubeMacPoolImage,
+		NMStateHandler:                 *nmStateHandlerImage,
+		OvsCni:                         *ovsCniImage,
+		OvsMarker:                      *ovsMarkerImage,
+		MultusArchitectures:            strings.Split(*multusArchitectures, ","),
+		LinuxBridgeCniArchitectures:    strings.Split(*linuxBridgeCniArchitectures, ","),
+		LinuxBridgeMarkerArchitectures: strings.Split(*linuxBridgeMarkerArchitectures, ","),
+		KubeMacPoolArchitectures:       strings.Split(*kubeMacPoolArchitectures, ","),
+		NMStateHandlerArchitectures:    strings.Split(*nmStateHandlerArchitectures, ","),
+		OvsCniArchitectures:            strings.Split(*ovsCniArchitectures, ","),
+		OvsMarkerArchitectures:         strings.Split(*ovsMarkerArchitectures, ","),
+	}
+	setOperatorEnvironment(*namespace, addonsImages)
+
+	config, err := readConfig(*configFile)
+	if err != nil {
+		log.Printf("failed to read NetworkAddonsConfig: %v", err)
+		os.Exit(1)
+	}
+
+	var openShiftNetworkConfig *osv1.Network
+	if *openShiftNetworkConfigFile != "" {
+		openShiftNetworkConfig, err = readOpenShiftNetworkConfig(*openShiftNetworkConfigFile)
+		if err != nil {
+			log.Printf("failed to read OpenShift Network operator config: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	clusterInfo := &network.ClusterInfo{
+		OpenShift4:          *openShift4,
+		SCCAvailable:        *sccAvailable,
+		MonitoringAvailable: *monitoringAvailable,
+	}
+
+	objs, err := render(&config.Spec, *manifestDir, openShiftNetworkConfig, clusterInfo, *generateKubeMacPoolRange)
+	if err != nil {
+		log.Printf("failed to render NetworkAddonsConfig: %v", err)
+		os.Exit(1)
+	}
+
+	if err := writeObjects(os.Stdout, objs); err != nil {
+		log.Printf("failed to print rendered objects: %v", err)
+		os.Exit(1)
+	}
+}
+
+// setOperatorEnvironment passes images and the namespace to rendering the same way the operator
+// gets them, through environment variables of its Deployment
+func setOperatorEnvironment(namespace string, addonsImages *components.AddonsImages) {
+	deployment := components.GetDeployment("", "", namespace, "", "", "", "", addonsImages)
+	for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+		if env.ValueFrom == nil {
+			os.Setenv(env.Name, env.Value)
+		}
+	}
+	os.Setenv("OPERATOR_NAMESPACE", namespace)
+	os.Setenv("OPERAND_NAMESPACE", namespace)
+}
+
+// readConfig reads NetworkAddonsConfig of any served API version and converts it to the hub version
+func readConfig(path string) (*opv1.NetworkAddonsConfig, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := runtime.NewScheme()
+	if err := apis.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	obj, _, err := serializer.NewCodecFactory(scheme).UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	converted, err := scheme.ConvertToVersion(obj, opv1.SchemeGroupVersion)
+	if err != nil {
+		return nil, err
+	}
+	config, ok := converted.(*opv1.NetworkAddonsConfig)
+	if !ok {
+		return nil, errors.Errorf("expected NetworkAddonsConfig, got %s", obj.GetObjectKind().GroupVersionKind().Kind)
+	}
+	return config, nil
+}
+
+func readOpenShiftNetworkConfig(path string) (*osv1.Network, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &osv1.Network{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// render canonicalizes, validates and fills defaults of the config, the same as the operator does
+// for a newly created config, and renders its objects customized by patches. An unset KubeMacPool
+// range is generated randomly only when it is explicitly allowed, otherwise the rendering fails, so
+// the output is always reproducible unless asked otherwise
+func render(conf *opv1.NetworkAddonsConfigSpec, manifestDir string, openShiftNetworkConfig *osv1.Network, clusterInfo *network.ClusterInfo, generateKubeMacPoolRange bool) ([]*unstructured.Unstructured, error) {
+	network.Canonicalize(conf)
+
+	if err := network.Validate(conf, openShiftNetworkConfig, clusterInfo); err != nil {
+		return nil, err
+	}
+
+	if conf.KubeMacPool != nil && (conf.KubeMacPool.RangeStart == "" || conf.KubeMacPool.RangeEnd == "") && !generateKubeMacPoolRange {
+		return nil, errors.New("KubeMacPool range is not set, set both rangeStart and rangeEnd or pass --generate-kubemacpool-range")
+	}
+
+	if err := network.FillDefaults(conf, nil); err != nil {
+		return nil, errors.Wrap(err, "failed to fill defaults")
+	}
+
+	objs, err := network.Render(conf, manifestDir, openShiftNetworkConfig, clusterInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return network.ApplyPatches(conf, objs)
+}
+
+func writeObjects(out io.Writer, objs []*unstructured.Unstructured) error {
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/components"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/network"
+)
+
+// Rewrite golden files by the current output, run as `go test ./cmd/cna-render -args -update`
+var update = flag.Bool("update", false, "update golden files of cna-render tests")
+
+const goldenFile = "testdata/rendered.yaml"
+
+var _ = Describe("cna-render", func() {
+	clusterInfo := &network.ClusterInfo{}
+
+	BeforeEach(func() {
+		setOperatorEnvironment(components.Namespace, (&components.AddonsImages{}).FillDefaults())
+	})
+
+	It("should render objects of the config as recorded in the golden file", func() {
+		config, err := readConfig("testdata/network-addons-config.yaml")
+		Expect(err).NotTo(HaveOccurred())
+
+		objs, err := render(&config.Spec, "../../data", nil, clusterInfo, false)
+		Expect(err).NotTo(HaveOccurred())
+
+		out := &bytes.Buffer{}
+		Expect(writeObjects(out, objs)).To(Succeed())
+
+		if *update {
+			Expect(ioutil.WriteFile(goldenFile, out.Bytes(), 0644)).To(Succeed())
+		}
+		golden, err := ioutil.ReadFile(goldenFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.String()).To(Equal(string(golden)))
+	})
+
+	Context("when KubeMacPool range is not set", func() {
+		conf := func() *opv1.NetworkAddonsConfigSpec {
+			return &opv1.NetworkAddonsConfigSpec{KubeMacPool: &opv1.KubeMacPool{}}
+		}
+
+		It("should fail, so the output is reproducible", func() {
+			_, err := render(conf(), "../../data", nil, clusterInfo, false)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should generate it when allowed", func() {
+			spec := conf()
+			_, err := render(spec, "../../data", nil, clusterInfo, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.KubeMacPool.RangeStart).NotTo(BeEmpty())
+			Expect(spec.KubeMacPool.RangeEnd).NotTo(BeEmpty())
+		})
+	})
+})
@@ -14,6 +14,7 @@ import (
 	"github.com/operator-framework/operator-sdk/pkg/metrics"
 	sdkVersion "github.com/operator-framework/operator-sdk/version"
 	"github.com/spf13/pflag"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -87,6 +88,10 @@ func main() {
 		log.Printf("failed adding openshift scheme to the client: %v", err)
 		os.Exit(1)
 	}
+	if err := extv1beta1.AddToScheme(mgr.GetScheme()); err != nil {
+		log.Printf("failed adding apiextensions scheme to the client: %v", err)
+		os.Exit(1)
+	}
 
 	// Setup all Controllers
 	if err := controller.AddToManager(mgr); err != nil {
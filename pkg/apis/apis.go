@@ -3,11 +3,13 @@ package apis
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 
+	v1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
 	"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
 )
 
 // AddToSchemes may be used to add all resources defined in the project to a Scheme
 var AddToSchemes = runtime.SchemeBuilder{
+	v1.SchemeBuilder.AddToScheme,
 	v1alpha1.SchemeBuilder.AddToScheme,
 }
 
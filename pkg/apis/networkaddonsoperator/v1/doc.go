@@ -0,0 +1,4 @@
+// Package v1 contains API Schema definitions for the networkaddonsoperator v1 API group
+// +k8s:deepcopy-gen=package,register
+// +groupName=networkaddonsoperator.network.kubevirt.io
+package v1
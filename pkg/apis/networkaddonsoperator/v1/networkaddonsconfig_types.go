@@ -0,0 +1,268 @@
+package v1
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetworkAddonsConfigSpec defines the desired state of NetworkAddonsConfig
+// +k8s:openapi-gen=true
+type NetworkAddonsConfigSpec struct {
+	// Multus deploys Multus meta plugin, allowing pods to be attached to multiple networks
+	Multus *Multus `json:"multus,omitempty"`
+	// LinuxBridge deploys Linux bridge CNI plugin and bridge marker
+	LinuxBridge *LinuxBridge `json:"linuxBridge,omitempty"`
+	// Ovs deploys Open vSwitch CNI plugin and marker
+	Ovs *Ovs `json:"ovs,omitempty"`
+	// KubeMacPool deploys MAC address pool manager for pods and virtual machines
+	KubeMacPool *KubeMacPool `json:"kubeMacPool,omitempty"`
+	// ImagePullPolicy used by containers of all deployed components
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// NMState deploys kubernetes-nmstate node network configuration handler
+	NMState *NMState `json:"nmstate,omitempty"`
+	// Placement is the default placement of all components, it can be overridden per component
+	Placement *Placement `json:"placement,omitempty"`
+	// DryRun stops the operator from changing deployed components. Changes it would do are
+	// reported in a ConfigMap instead
+	DryRun bool `json:"dryRun,omitempty"`
+	// Patches modify rendered objects before they are applied, allowing customizations which are
+	// not exposed by this API. They are applied in the given order
+	Patches []Patch `json:"patches,omitempty"`
+	// Monitoring deploys a ServiceMonitor and a PrometheusRule of the operator, it requires
+	// Prometheus operator to be installed on the cluster
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+}
+
+// PatchType is the format of a patch
+type PatchType string
+
+const (
+	// PatchTypeStrategicMerge is a strategic merge patch, as used by kubectl patch
+	PatchTypeStrategicMerge PatchType = "StrategicMerge"
+	// PatchTypeJSON is a JSON patch as defined by RFC 6902
+	PatchTypeJSON PatchType = "JSON"
+)
+
+// Patch modifies an object rendered by the operator
+// +k8s:openapi-gen=true
+type Patch struct {
+	// Target identifies the patched object
+	Target PatchTarget `json:"target"`
+	// Type of the patch, either StrategicMerge or JSON
+	Type PatchType `json:"type"`
+	// Patch is the content of the patch, in YAML or JSON
+	Patch string `json:"patch"`
+}
+
+// PatchTarget identifies an object rendered by the operator
+// +k8s:openapi-gen=true
+type PatchTarget struct {
+	// APIVersion of the object, e.g. apps/v1
+	APIVersion string `json:"apiVersion"`
+	// Kind of the object, e.g. DaemonSet
+	Kind string `json:"kind"`
+	// Namespace of the object, empty for cluster-scoped objects
+	Namespace string `json:"namespace,omitempty"`
+	// Name of the object
+	Name string `json:"name"`
+}
+
+// Placement describes where pods of a component are scheduled. Each field which is set replaces
+// the respective default of the component
+// +k8s:openapi-gen=true
+type Placement struct {
+	// NodeSelector limits the component to nodes with matching labels
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Affinity is a group of affinity scheduling rules of the component
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// Tolerations allow the component to be scheduled on nodes with matching taints
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// Multus plugin enables attaching multiple network interfaces to Pods in Kubernetes
+// +k8s:openapi-gen=true
+type Multus struct {
+	// Placement of the component, overrides the global placement
+	Placement *Placement `json:"placement,omitempty"`
+	// Resources override compute resource requirements of containers of the component, keyed by
+	// container name
+	Resources map[string]corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Image of Multus, overrides the default image of the operator
+	Image string `json:"image,omitempty"`
+	// ProgressDeadline is the maximum time the component may be rolling out, after which it is
+	// reported as Degraded. Defaults to 10m
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
+}
+
+// LinuxBridge plugin allows users to create a bridge and add the host and the container to it
+// +k8s:openapi-gen=true
+type LinuxBridge struct {
+	// Placement of the component, overrides the global placement
+	Placement *Placement `json:"placement,omitempty"`
+	// Resources override compute resource requirements of containers of the component, keyed by
+	// container name
+	Resources map[string]corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Image of Linux bridge CNI plugin, overrides the default image of the operator
+	Image string `json:"image,omitempty"`
+	// MarkerImage of bridge marker, overrides the default image of the operator
+	MarkerImage string `json:"markerImage,omitempty"`
+	// ProgressDeadline is the maximum time the component may be rolling out, after which it is
+	// reported as Degraded. Defaults to 10m
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
+}
+
+// Ovs plugin allows users to define Kubernetes networks on top of Open vSwitch bridges available on nodes
+// +k8s:openapi-gen=true
+type Ovs struct {
+	// Placement of the component, overrides the global placement
+	Placement *Placement `json:"placement,omitempty"`
+	// Resources override compute resource requirements of containers of the component, keyed by
+	// container name
+	Resources map[string]corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Image of Open vSwitch CNI plugin, overrides the default image of the operator
+	Image string `json:"image,omitempty"`
+	// MarkerImage of Open vSwitch marker, overrides the default image of the operator
+	MarkerImage string `json:"markerImage,omitempty"`
+	// ProgressDeadline is the maximum time the component may be rolling out, after which it is
+	// reported as Degraded. Defaults to 10m
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
+}
+
+// NMState is a declarative node network configuration driven through Kubernetes API
+// +k8s:openapi-gen=true
+type NMState struct {
+	// Placement of the component, overrides the global placement
+	Placement *Placement `json:"placement,omitempty"`
+	// Resources override compute resource requirements of containers of the component, keyed by
+	// container name
+	Resources map[string]corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Image of kubernetes-nmstate handler, overrides the default image of the operator
+	Image string `json:"image,omitempty"`
+	// ProgressDeadline is the maximum time the component may be rolling out, after which it is
+	// reported as Degraded. Defaults to 10m
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
+}
+
+// Monitoring of the operator and deployed components by Prometheus
+// +k8s:openapi-gen=true
+type Monitoring struct{}
+
+// KubeMacPool plugin manages MAC allocation to Pods and VMs in Kubernetes
+// +k8s:openapi-gen=true
+type KubeMacPool struct {
+	// RangeStart defines the first MAC address in the pool, a random range is generated if both
+	// RangeStart and RangeEnd are empty
+	RangeStart string `json:"rangeStart,omitempty"`
+	// RangeEnd defines the last MAC address in the pool
+	RangeEnd string `json:"rangeEnd,omitempty"`
+	// Placement of the component, overrides the global placement
+	Placement *Placement `json:"placement,omitempty"`
+	// Resources override compute resource requirements of containers of the component, keyed by
+	// container name
+	Resources map[string]corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Image of KubeMacPool, overrides the default image of the operator
+	Image string `json:"image,omitempty"`
+	// ProgressDeadline is the maximum time the component may be rolling out, after which it is
+	// reported as Degraded. Defaults to 10m
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
+}
+
+// NetworkAddonsConfigStatus defines the observed state of NetworkAddonsConfig
+// +k8s:openapi-gen=true
+type NetworkAddonsConfigStatus struct {
+	// OperatorVersion is the version of the running operator
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+	// ObservedVersion is the version of the operator which deployed currently running components
+	ObservedVersion string `json:"observedVersion,omitempty"`
+	// TargetVersion is the version of the operator which is being deployed
+	TargetVersion string `json:"targetVersion,omitempty"`
+	// Conditions describe the state of the deployment, they follow Available, Progressing and Degraded pattern
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"  patchStrategy:"merge" patchMergeKey:"type"`
+	// Containers lists all containers deployed by the operator together with their images
+	Containers []Container `json:"containers,omitempty"`
+	// Patches lists patches applied on deployed objects
+	Patches []AppliedPatch `json:"patches,omitempty"`
+	// Components report state of each deployed component, conditions of the whole config are
+	// derived from them
+	Components []ComponentStatus `json:"components,omitempty"`
+}
+
+// ComponentStatus is the observed state of a deployed component
+// +k8s:openapi-gen=true
+type ComponentStatus struct {
+	// Name of the component, e.g. LinuxBridge
+	Name string `json:"name"`
+	// Version of the operator which deployed currently running pods of the component
+	Version string `json:"version,omitempty"`
+	// Conditions describe the state of the component, they follow Available, Progressing and Degraded pattern
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"  patchStrategy:"merge" patchMergeKey:"type"`
+	// Workloads lists DaemonSets and Deployments of the component
+	Workloads []WorkloadStatus `json:"workloads,omitempty"`
+}
+
+// WorkloadStatus is the observed state of a DaemonSet or Deployment deployed by the operator
+// +k8s:openapi-gen=true
+type WorkloadStatus struct {
+	// Kind of the workload, either DaemonSet or Deployment
+	Kind string `json:"kind"`
+	// Namespace of the workload
+	Namespace string `json:"namespace"`
+	// Name of the workload
+	Name string `json:"name"`
+	// Desired is the number of pods which should be running
+	Desired int32 `json:"desired"`
+	// Ready is the number of available pods
+	Ready int32 `json:"ready"`
+}
+
+// AppliedPatch is a patch applied on an object deployed by the operator
+// +k8s:openapi-gen=true
+type AppliedPatch struct {
+	// Target identifies the patched object
+	Target PatchTarget `json:"target"`
+	// Type of the patch
+	Type PatchType `json:"type"`
+}
+
+// Container is a container deployed by the operator
+// +k8s:openapi-gen=true
+type Container struct {
+	// ParentKind is the kind of the object owning the container, e.g. DaemonSet
+	ParentKind string `json:"parentKind"`
+	// ParentName is the name of the object owning the container
+	ParentName string `json:"parentName"`
+	// Name of the container
+	Name string `json:"name"`
+	// Image used by the container
+	Image string `json:"image"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NetworkAddonsConfig is the Schema for the networkaddonsconfigs API
+// +k8s:openapi-gen=true
+type NetworkAddonsConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NetworkAddonsConfigSpec   `json:"spec,omitempty"`
+	Status NetworkAddonsConfigStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NetworkAddonsConfigList contains a list of NetworkAddonsConfig
+type NetworkAddonsConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NetworkAddonsConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NetworkAddonsConfig{}, &NetworkAddonsConfigList{})
+}
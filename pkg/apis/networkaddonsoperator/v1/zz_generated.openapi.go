@@ -0,0 +1,826 @@
+// +build !ignore_autogenerated
+
+// Code generated by openapi-gen. DO NOT EDIT.
+
+// This file was autogenerated by openapi-gen. Do not edit it manually!
+
+package v1
+
+import (
+	spec "github.com/go-openapi/spec"
+	common "k8s.io/kube-openapi/pkg/common"
+)
+
+func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
+	return map[string]common.OpenAPIDefinition{
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.AppliedPatch":              schema_pkg_apis_networkaddonsoperator_v1_AppliedPatch(ref),
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.ComponentStatus":           schema_pkg_apis_networkaddonsoperator_v1_ComponentStatus(ref),
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Container":                 schema_pkg_apis_networkaddonsoperator_v1_Container(ref),
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.KubeMacPool":               schema_pkg_apis_networkaddonsoperator_v1_KubeMacPool(ref),
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.LinuxBridge":               schema_pkg_apis_networkaddonsoperator_v1_LinuxBridge(ref),
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Monitoring":                schema_pkg_apis_networkaddonsoperator_v1_Monitoring(ref),
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Multus":                    schema_pkg_apis_networkaddonsoperator_v1_Multus(ref),
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.NMState":                   schema_pkg_apis_networkaddonsoperator_v1_NMState(ref),
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.NetworkAddonsConfig":       schema_pkg_apis_networkaddonsoperator_v1_NetworkAddonsConfig(ref),
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.NetworkAddonsConfigSpec":   schema_pkg_apis_networkaddonsoperator_v1_NetworkAddonsConfigSpec(ref),
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.NetworkAddonsConfigStatus": schema_pkg_apis_networkaddonsoperator_v1_NetworkAddonsConfigStatus(ref),
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Ovs":                       schema_pkg_apis_networkaddonsoperator_v1_Ovs(ref),
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Patch":                     schema_pkg_apis_networkaddonsoperator_v1_Patch(ref),
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.PatchTarget":               schema_pkg_apis_networkaddonsoperator_v1_PatchTarget(ref),
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Placement":                 schema_pkg_apis_networkaddonsoperator_v1_Placement(ref),
+		"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.WorkloadStatus":            schema_pkg_apis_networkaddonsoperator_v1_WorkloadStatus(ref),
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_AppliedPatch(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AppliedPatch is a patch applied on an object deployed by the operator",
+				Properties: map[string]spec.Schema{
+					"target": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Target identifies the patched object",
+							Ref:         ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.PatchTarget"),
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the patch",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"target", "type"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.PatchTarget"},
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_ComponentStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ComponentStatus is the observed state of a deployed component",
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the component, e.g. LinuxBridge",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version of the operator which deployed currently running pods of the component",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-patch-merge-key": "type",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions describe the state of the component, they follow Available, Progressing and Degraded pattern",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/openshift/custom-resource-status/conditions/v1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"workloads": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Workloads lists DaemonSets and Deployments of the component",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.WorkloadStatus"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.WorkloadStatus", "github.com/openshift/custom-resource-status/conditions/v1.Condition"},
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_Container(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Container is a container deployed by the operator",
+				Properties: map[string]spec.Schema{
+					"parentKind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ParentKind is the kind of the object owning the container, e.g. DaemonSet",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"parentName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ParentName is the name of the object owning the container",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the container",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image used by the container",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"parentKind", "parentName", "name", "image"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_KubeMacPool(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "KubeMacPool plugin manages MAC allocation to Pods and VMs in Kubernetes",
+				Properties: map[string]spec.Schema{
+					"rangeStart": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RangeStart defines the first MAC address in the pool, a random range is generated if both RangeStart and RangeEnd are empty",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rangeEnd": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RangeEnd defines the last MAC address in the pool",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"placement": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Placement of the component, overrides the global placement",
+							Ref:         ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Placement"),
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources override compute resource requirements of containers of the component, keyed by container name",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.ResourceRequirements"),
+									},
+								},
+							},
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image of KubeMacPool, overrides the default image of the operator",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"progressDeadline": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProgressDeadline is the maximum time the component may be rolling out, after which it is reported as Degraded. Defaults to 10m",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Placement", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_LinuxBridge(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LinuxBridge plugin allows users to create a bridge and add the host and the container to it",
+				Properties: map[string]spec.Schema{
+					"placement": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Placement of the component, overrides the global placement",
+							Ref:         ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Placement"),
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources override compute resource requirements of containers of the component, keyed by container name",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.ResourceRequirements"),
+									},
+								},
+							},
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image of Linux bridge CNI plugin, overrides the default image of the operator",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"markerImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MarkerImage of bridge marker, overrides the default image of the operator",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"progressDeadline": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProgressDeadline is the maximum time the component may be rolling out, after which it is reported as Degraded. Defaults to 10m",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Placement", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_Monitoring(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Monitoring of the operator and deployed components by Prometheus",
+				Properties:  map[string]spec.Schema{},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_Multus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Multus plugin enables attaching multiple network interfaces to Pods in Kubernetes",
+				Properties: map[string]spec.Schema{
+					"placement": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Placement of the component, overrides the global placement",
+							Ref:         ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Placement"),
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources override compute resource requirements of containers of the component, keyed by container name",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.ResourceRequirements"),
+									},
+								},
+							},
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image of Multus, overrides the default image of the operator",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"progressDeadline": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProgressDeadline is the maximum time the component may be rolling out, after which it is reported as Degraded. Defaults to 10m",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Placement", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_NMState(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NMState is a declarative node network configuration driven through Kubernetes API",
+				Properties: map[string]spec.Schema{
+					"placement": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Placement of the component, overrides the global placement",
+							Ref:         ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Placement"),
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources override compute resource requirements of containers of the component, keyed by container name",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.ResourceRequirements"),
+									},
+								},
+							},
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image of kubernetes-nmstate handler, overrides the default image of the operator",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"progressDeadline": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProgressDeadline is the maximum time the component may be rolling out, after which it is reported as Degraded. Defaults to 10m",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Placement", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_NetworkAddonsConfig(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkAddonsConfig is the Schema for the networkaddonsconfigs API",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.NetworkAddonsConfigSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.NetworkAddonsConfigStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.NetworkAddonsConfigSpec", "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.NetworkAddonsConfigStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_NetworkAddonsConfigSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkAddonsConfigSpec defines the desired state of NetworkAddonsConfig",
+				Properties: map[string]spec.Schema{
+					"multus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Multus deploys Multus meta plugin, allowing pods to be attached to multiple networks",
+							Ref:         ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Multus"),
+						},
+					},
+					"linuxBridge": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LinuxBridge deploys Linux bridge CNI plugin and bridge marker",
+							Ref:         ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.LinuxBridge"),
+						},
+					},
+					"ovs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ovs deploys Open vSwitch CNI plugin and marker",
+							Ref:         ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Ovs"),
+						},
+					},
+					"kubeMacPool": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KubeMacPool deploys MAC address pool manager for pods and virtual machines",
+							Ref:         ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.KubeMacPool"),
+						},
+					},
+					"imagePullPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImagePullPolicy used by containers of all deployed components",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"nmstate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NMState deploys kubernetes-nmstate node network configuration handler",
+							Ref:         ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.NMState"),
+						},
+					},
+					"placement": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Placement is the default placement of all components, it can be overridden per component",
+							Ref:         ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Placement"),
+						},
+					},
+					"dryRun": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DryRun stops the operator from changing deployed components. Changes it would do are reported in a ConfigMap instead",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"patches": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Patches modify rendered objects before they are applied, allowing customizations which are not exposed by this API. They are applied in the given order",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Patch"),
+									},
+								},
+							},
+						},
+					},
+					"monitoring": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Monitoring deploys a ServiceMonitor and a PrometheusRule of the operator, it requires Prometheus operator to be installed on the cluster",
+							Ref:         ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Monitoring"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.KubeMacPool", "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.LinuxBridge", "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Monitoring", "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Multus", "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.NMState", "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Ovs", "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Patch", "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Placement"},
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_NetworkAddonsConfigStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "NetworkAddonsConfigStatus defines the observed state of NetworkAddonsConfig",
+				Properties: map[string]spec.Schema{
+					"operatorVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OperatorVersion is the version of the running operator",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"observedVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedVersion is the version of the operator which deployed currently running components",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"targetVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TargetVersion is the version of the operator which is being deployed",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-patch-merge-key": "type",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions describe the state of the deployment, they follow Available, Progressing and Degraded pattern",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/openshift/custom-resource-status/conditions/v1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"containers": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Containers lists all containers deployed by the operator together with their images",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Container"),
+									},
+								},
+							},
+						},
+					},
+					"patches": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Patches lists patches applied on deployed objects",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.AppliedPatch"),
+									},
+								},
+							},
+						},
+					},
+					"components": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Components report state of each deployed component, conditions of the whole config are derived from them",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.ComponentStatus"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.AppliedPatch", "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.ComponentStatus", "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Container", "github.com/openshift/custom-resource-status/conditions/v1.Condition"},
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_Ovs(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Ovs plugin allows users to define Kubernetes networks on top of Open vSwitch bridges available on nodes",
+				Properties: map[string]spec.Schema{
+					"placement": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Placement of the component, overrides the global placement",
+							Ref:         ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Placement"),
+						},
+					},
+					"resources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resources override compute resource requirements of containers of the component, keyed by container name",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.ResourceRequirements"),
+									},
+								},
+							},
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image of Open vSwitch CNI plugin, overrides the default image of the operator",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"markerImage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MarkerImage of Open vSwitch marker, overrides the default image of the operator",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"progressDeadline": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProgressDeadline is the maximum time the component may be rolling out, after which it is reported as Degraded. Defaults to 10m",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.Placement", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_Patch(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Patch modifies an object rendered by the operator",
+				Properties: map[string]spec.Schema{
+					"target": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Target identifies the patched object",
+							Ref:         ref("github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.PatchTarget"),
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the patch, either StrategicMerge or JSON",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"patch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Patch is the content of the patch, in YAML or JSON",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"target", "type", "patch"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.PatchTarget"},
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_PatchTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "PatchTarget identifies an object rendered by the operator",
+				Properties: map[string]spec.Schema{
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion of the object, e.g. apps/v1",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind of the object, e.g. DaemonSet",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace of the object, empty for cluster-scoped objects",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the object",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"apiVersion", "kind", "name"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_Placement(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "Placement describes where pods of a component are scheduled. Each field which is set replaces the respective default of the component",
+				Properties: map[string]spec.Schema{
+					"nodeSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeSelector limits the component to nodes with matching labels",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"affinity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Affinity is a group of affinity scheduling rules of the component",
+							Ref:         ref("k8s.io/api/core/v1.Affinity"),
+						},
+					},
+					"tolerations": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tolerations allow the component to be scheduled on nodes with matching taints",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/core/v1.Toleration"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Toleration"},
+	}
+}
+
+func schema_pkg_apis_networkaddonsoperator_v1_WorkloadStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "WorkloadStatus is the observed state of a DaemonSet or Deployment deployed by the operator",
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind of the workload, either DaemonSet or Deployment",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace of the workload",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the workload",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"desired": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Desired is the number of pods which should be running",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"ready": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Ready is the number of available pods",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"kind", "namespace", "name", "desired", "ready"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
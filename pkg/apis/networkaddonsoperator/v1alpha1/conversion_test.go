@@ -0,0 +1,60 @@
+package v1alpha1
+
+import (
+	fuzz "github.com/google/gofuzz"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+var _ = Describe("Testing conversion between v1alpha1 and v1", func() {
+	var scheme *runtime.Scheme
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(SchemeBuilder.AddToScheme(scheme)).To(Succeed())
+		Expect(opv1.SchemeBuilder.AddToScheme(scheme)).To(Succeed())
+	})
+
+	Context("when NetworkAddonsConfig is converted from v1alpha1 to v1 and back", func() {
+		It("should not lose any data", func() {
+			fuzzer := fuzz.New().NilChance(0.5).NumElements(0, 3)
+			for i := 0; i < 100; i++ {
+				original := &NetworkAddonsConfig{}
+				fuzzer.Fuzz(original)
+				original.TypeMeta.APIVersion = SchemeGroupVersion.String()
+				original.TypeMeta.Kind = "NetworkAddonsConfig"
+
+				converted, err := scheme.ConvertToVersion(original.DeepCopy(), opv1.SchemeGroupVersion)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(converted).To(BeAssignableToTypeOf(&opv1.NetworkAddonsConfig{}))
+
+				roundTripped, err := scheme.ConvertToVersion(converted, SchemeGroupVersion)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(roundTripped).To(Equal(original))
+			}
+		})
+	})
+
+	Context("when NetworkAddonsConfig is converted from v1 to v1alpha1 and back", func() {
+		It("should not lose any data", func() {
+			fuzzer := fuzz.New().NilChance(0.5).NumElements(0, 3)
+			for i := 0; i < 100; i++ {
+				original := &opv1.NetworkAddonsConfig{}
+				fuzzer.Fuzz(original)
+				original.TypeMeta.APIVersion = opv1.SchemeGroupVersion.String()
+				original.TypeMeta.Kind = "NetworkAddonsConfig"
+
+				converted, err := scheme.ConvertToVersion(original.DeepCopy(), SchemeGroupVersion)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(converted).To(BeAssignableToTypeOf(&NetworkAddonsConfig{}))
+
+				roundTripped, err := scheme.ConvertToVersion(converted, opv1.SchemeGroupVersion)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(roundTripped).To(Equal(original))
+			}
+		})
+	})
+})
@@ -1,4 +1,6 @@
 // Package v1alpha1 contains API Schema definitions for the networkaddonsoperator v1alpha1 API group
+// This version is deprecated in favor of v1 and it is converted to it using generated conversions
 // +k8s:deepcopy-gen=package,register
+// +k8s:conversion-gen=github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1
 // +groupName=networkaddonsoperator.network.kubevirt.io
 package v1alpha1
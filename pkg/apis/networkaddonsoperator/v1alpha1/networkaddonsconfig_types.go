@@ -15,24 +15,86 @@ type NetworkAddonsConfigSpec struct {
 	KubeMacPool     *KubeMacPool      `json:"kubeMacPool,omitempty"`
 	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
 	NMState         *NMState          `json:"nmstate,omitempty"`
+	Placement       *Placement        `json:"placement,omitempty"`
+	DryRun          bool              `json:"dryRun,omitempty"`
+	Patches         []Patch           `json:"patches,omitempty"`
+	Monitoring      *Monitoring       `json:"monitoring,omitempty"`
+}
+
+type PatchType string
+
+const (
+	PatchTypeStrategicMerge PatchType = "StrategicMerge"
+	PatchTypeJSON           PatchType = "JSON"
+)
+
+// +k8s:openapi-gen=true
+type Patch struct {
+	Target PatchTarget `json:"target"`
+	Type   PatchType   `json:"type"`
+	Patch  string      `json:"patch"`
+}
+
+// +k8s:openapi-gen=true
+type PatchTarget struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// +k8s:openapi-gen=true
+type Placement struct {
+	NodeSelector map[string]string   `json:"nodeSelector,omitempty"`
+	Affinity     *corev1.Affinity    `json:"affinity,omitempty"`
+	Tolerations  []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type Multus struct {
+	Placement        *Placement                             `json:"placement,omitempty"`
+	Resources        map[string]corev1.ResourceRequirements `json:"resources,omitempty"`
+	Image            string                                 `json:"image,omitempty"`
+	ProgressDeadline *metav1.Duration                       `json:"progressDeadline,omitempty"`
 }
 
 // +k8s:openapi-gen=true
-type Multus struct{}
+type LinuxBridge struct {
+	Placement        *Placement                             `json:"placement,omitempty"`
+	Resources        map[string]corev1.ResourceRequirements `json:"resources,omitempty"`
+	Image            string                                 `json:"image,omitempty"`
+	MarkerImage      string                                 `json:"markerImage,omitempty"`
+	ProgressDeadline *metav1.Duration                       `json:"progressDeadline,omitempty"`
+}
 
 // +k8s:openapi-gen=true
-type LinuxBridge struct{}
+type Ovs struct {
+	Placement        *Placement                             `json:"placement,omitempty"`
+	Resources        map[string]corev1.ResourceRequirements `json:"resources,omitempty"`
+	Image            string                                 `json:"image,omitempty"`
+	MarkerImage      string                                 `json:"markerImage,omitempty"`
+	ProgressDeadline *metav1.Duration                       `json:"progressDeadline,omitempty"`
+}
 
 // +k8s:openapi-gen=true
-type Ovs struct{}
+type NMState struct {
+	Placement        *Placement                             `json:"placement,omitempty"`
+	Resources        map[string]corev1.ResourceRequirements `json:"resources,omitempty"`
+	Image            string                                 `json:"image,omitempty"`
+	ProgressDeadline *metav1.Duration                       `json:"progressDeadline,omitempty"`
+}
 
 // +k8s:openapi-gen=true
-type NMState struct{}
+type Monitoring struct{}
 
 // +k8s:openapi-gen=true
 type KubeMacPool struct {
-	RangeStart string `json:"rangeStart,omitempty"`
-	RangeEnd   string `json:"rangeEnd,omitempty"`
+	RangeStart       string                                 `json:"rangeStart,omitempty"`
+	RangeEnd         string                                 `json:"rangeEnd,omitempty"`
+	Placement        *Placement                             `json:"placement,omitempty"`
+	Resources        map[string]corev1.ResourceRequirements `json:"resources,omitempty"`
+	Image            string                                 `json:"image,omitempty"`
+	ProgressDeadline *metav1.Duration                       `json:"progressDeadline,omitempty"`
 }
 
 // NetworkAddonsConfigStatus defines the observed state of NetworkAddonsConfig
@@ -43,6 +105,31 @@ type NetworkAddonsConfigStatus struct {
 	TargetVersion   string                   `json:"targetVersion,omitempty"`
 	Conditions      []conditionsv1.Condition `json:"conditions,omitempty"  patchStrategy:"merge" patchMergeKey:"type"`
 	Containers      []Container              `json:"containers,omitempty"`
+	Patches         []AppliedPatch           `json:"patches,omitempty"`
+	Components      []ComponentStatus        `json:"components,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type ComponentStatus struct {
+	Name       string                   `json:"name"`
+	Version    string                   `json:"version,omitempty"`
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"  patchStrategy:"merge" patchMergeKey:"type"`
+	Workloads  []WorkloadStatus         `json:"workloads,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type WorkloadStatus struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Desired   int32  `json:"desired"`
+	Ready     int32  `json:"ready"`
+}
+
+// +k8s:openapi-gen=true
+type AppliedPatch struct {
+	Target PatchTarget `json:"target"`
+	Type   PatchType   `json:"type"`
 }
 
 type Container struct {
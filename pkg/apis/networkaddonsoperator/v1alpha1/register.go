@@ -1,6 +1,3 @@
-// Package v1alpha1 contains API Schema definitions for the networkaddonsoperator v1alpha1 API group
-// +k8s:deepcopy-gen=package,register
-// +groupName=networkaddonsoperator.network.kubevirt.io
 package v1alpha1
 
 import (
@@ -14,4 +11,7 @@ var (
 
 	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
 	SchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
+
+	// localSchemeBuilder is used by generated conversions to register themselves
+	localSchemeBuilder = &SchemeBuilder.SchemeBuilder
 )
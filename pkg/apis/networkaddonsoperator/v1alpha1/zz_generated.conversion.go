@@ -0,0 +1,660 @@
+// +build !ignore_autogenerated
+
+// Code generated by conversion-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	unsafe "unsafe"
+
+	v1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	conversion "k8s.io/apimachinery/pkg/conversion"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	localSchemeBuilder.Register(RegisterConversions)
+}
+
+// RegisterConversions adds conversion functions to the given scheme.
+// Public to allow building arbitrary schemes.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AppliedPatch)(nil), (*v1.AppliedPatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AppliedPatch_To_v1_AppliedPatch(a.(*AppliedPatch), b.(*v1.AppliedPatch), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.AppliedPatch)(nil), (*AppliedPatch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_AppliedPatch_To_v1alpha1_AppliedPatch(a.(*v1.AppliedPatch), b.(*AppliedPatch), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ComponentStatus)(nil), (*v1.ComponentStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ComponentStatus_To_v1_ComponentStatus(a.(*ComponentStatus), b.(*v1.ComponentStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ComponentStatus)(nil), (*ComponentStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ComponentStatus_To_v1alpha1_ComponentStatus(a.(*v1.ComponentStatus), b.(*ComponentStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Container)(nil), (*v1.Container)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Container_To_v1_Container(a.(*Container), b.(*v1.Container), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.Container)(nil), (*Container)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_Container_To_v1alpha1_Container(a.(*v1.Container), b.(*Container), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KubeMacPool)(nil), (*v1.KubeMacPool)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KubeMacPool_To_v1_KubeMacPool(a.(*KubeMacPool), b.(*v1.KubeMacPool), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.KubeMacPool)(nil), (*KubeMacPool)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_KubeMacPool_To_v1alpha1_KubeMacPool(a.(*v1.KubeMacPool), b.(*KubeMacPool), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LinuxBridge)(nil), (*v1.LinuxBridge)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LinuxBridge_To_v1_LinuxBridge(a.(*LinuxBridge), b.(*v1.LinuxBridge), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.LinuxBridge)(nil), (*LinuxBridge)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_LinuxBridge_To_v1alpha1_LinuxBridge(a.(*v1.LinuxBridge), b.(*LinuxBridge), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Monitoring)(nil), (*v1.Monitoring)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Monitoring_To_v1_Monitoring(a.(*Monitoring), b.(*v1.Monitoring), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.Monitoring)(nil), (*Monitoring)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_Monitoring_To_v1alpha1_Monitoring(a.(*v1.Monitoring), b.(*Monitoring), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Multus)(nil), (*v1.Multus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Multus_To_v1_Multus(a.(*Multus), b.(*v1.Multus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.Multus)(nil), (*Multus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_Multus_To_v1alpha1_Multus(a.(*v1.Multus), b.(*Multus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NMState)(nil), (*v1.NMState)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NMState_To_v1_NMState(a.(*NMState), b.(*v1.NMState), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.NMState)(nil), (*NMState)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_NMState_To_v1alpha1_NMState(a.(*v1.NMState), b.(*NMState), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkAddonsConfig)(nil), (*v1.NetworkAddonsConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkAddonsConfig_To_v1_NetworkAddonsConfig(a.(*NetworkAddonsConfig), b.(*v1.NetworkAddonsConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.NetworkAddonsConfig)(nil), (*NetworkAddonsConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_NetworkAddonsConfig_To_v1alpha1_NetworkAddonsConfig(a.(*v1.NetworkAddonsConfig), b.(*NetworkAddonsConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkAddonsConfigList)(nil), (*v1.NetworkAddonsConfigList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkAddonsConfigList_To_v1_NetworkAddonsConfigList(a.(*NetworkAddonsConfigList), b.(*v1.NetworkAddonsConfigList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.NetworkAddonsConfigList)(nil), (*NetworkAddonsConfigList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_NetworkAddonsConfigList_To_v1alpha1_NetworkAddonsConfigList(a.(*v1.NetworkAddonsConfigList), b.(*NetworkAddonsConfigList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkAddonsConfigSpec)(nil), (*v1.NetworkAddonsConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkAddonsConfigSpec_To_v1_NetworkAddonsConfigSpec(a.(*NetworkAddonsConfigSpec), b.(*v1.NetworkAddonsConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.NetworkAddonsConfigSpec)(nil), (*NetworkAddonsConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_NetworkAddonsConfigSpec_To_v1alpha1_NetworkAddonsConfigSpec(a.(*v1.NetworkAddonsConfigSpec), b.(*NetworkAddonsConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NetworkAddonsConfigStatus)(nil), (*v1.NetworkAddonsConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NetworkAddonsConfigStatus_To_v1_NetworkAddonsConfigStatus(a.(*NetworkAddonsConfigStatus), b.(*v1.NetworkAddonsConfigStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.NetworkAddonsConfigStatus)(nil), (*NetworkAddonsConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_NetworkAddonsConfigStatus_To_v1alpha1_NetworkAddonsConfigStatus(a.(*v1.NetworkAddonsConfigStatus), b.(*NetworkAddonsConfigStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Ovs)(nil), (*v1.Ovs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Ovs_To_v1_Ovs(a.(*Ovs), b.(*v1.Ovs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.Ovs)(nil), (*Ovs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_Ovs_To_v1alpha1_Ovs(a.(*v1.Ovs), b.(*Ovs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Patch)(nil), (*v1.Patch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Patch_To_v1_Patch(a.(*Patch), b.(*v1.Patch), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.Patch)(nil), (*Patch)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_Patch_To_v1alpha1_Patch(a.(*v1.Patch), b.(*Patch), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PatchTarget)(nil), (*v1.PatchTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PatchTarget_To_v1_PatchTarget(a.(*PatchTarget), b.(*v1.PatchTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.PatchTarget)(nil), (*PatchTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_PatchTarget_To_v1alpha1_PatchTarget(a.(*v1.PatchTarget), b.(*PatchTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Placement)(nil), (*v1.Placement)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Placement_To_v1_Placement(a.(*Placement), b.(*v1.Placement), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.Placement)(nil), (*Placement)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_Placement_To_v1alpha1_Placement(a.(*v1.Placement), b.(*Placement), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*WorkloadStatus)(nil), (*v1.WorkloadStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_WorkloadStatus_To_v1_WorkloadStatus(a.(*WorkloadStatus), b.(*v1.WorkloadStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.WorkloadStatus)(nil), (*WorkloadStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_WorkloadStatus_To_v1alpha1_WorkloadStatus(a.(*v1.WorkloadStatus), b.(*WorkloadStatus), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_AppliedPatch_To_v1_AppliedPatch(in *AppliedPatch, out *v1.AppliedPatch, s conversion.Scope) error {
+	if err := Convert_v1alpha1_PatchTarget_To_v1_PatchTarget(&in.Target, &out.Target, s); err != nil {
+		return err
+	}
+	out.Type = v1.PatchType(in.Type)
+	return nil
+}
+
+// Convert_v1alpha1_AppliedPatch_To_v1_AppliedPatch is an autogenerated conversion function.
+func Convert_v1alpha1_AppliedPatch_To_v1_AppliedPatch(in *AppliedPatch, out *v1.AppliedPatch, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AppliedPatch_To_v1_AppliedPatch(in, out, s)
+}
+
+func autoConvert_v1_AppliedPatch_To_v1alpha1_AppliedPatch(in *v1.AppliedPatch, out *AppliedPatch, s conversion.Scope) error {
+	if err := Convert_v1_PatchTarget_To_v1alpha1_PatchTarget(&in.Target, &out.Target, s); err != nil {
+		return err
+	}
+	out.Type = PatchType(in.Type)
+	return nil
+}
+
+// Convert_v1_AppliedPatch_To_v1alpha1_AppliedPatch is an autogenerated conversion function.
+func Convert_v1_AppliedPatch_To_v1alpha1_AppliedPatch(in *v1.AppliedPatch, out *AppliedPatch, s conversion.Scope) error {
+	return autoConvert_v1_AppliedPatch_To_v1alpha1_AppliedPatch(in, out, s)
+}
+
+func autoConvert_v1alpha1_ComponentStatus_To_v1_ComponentStatus(in *ComponentStatus, out *v1.ComponentStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	out.Conditions = *(*[]conditionsv1.Condition)(unsafe.Pointer(&in.Conditions))
+	out.Workloads = *(*[]v1.WorkloadStatus)(unsafe.Pointer(&in.Workloads))
+	return nil
+}
+
+// Convert_v1alpha1_ComponentStatus_To_v1_ComponentStatus is an autogenerated conversion function.
+func Convert_v1alpha1_ComponentStatus_To_v1_ComponentStatus(in *ComponentStatus, out *v1.ComponentStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ComponentStatus_To_v1_ComponentStatus(in, out, s)
+}
+
+func autoConvert_v1_ComponentStatus_To_v1alpha1_ComponentStatus(in *v1.ComponentStatus, out *ComponentStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Version = in.Version
+	out.Conditions = *(*[]conditionsv1.Condition)(unsafe.Pointer(&in.Conditions))
+	out.Workloads = *(*[]WorkloadStatus)(unsafe.Pointer(&in.Workloads))
+	return nil
+}
+
+// Convert_v1_ComponentStatus_To_v1alpha1_ComponentStatus is an autogenerated conversion function.
+func Convert_v1_ComponentStatus_To_v1alpha1_ComponentStatus(in *v1.ComponentStatus, out *ComponentStatus, s conversion.Scope) error {
+	return autoConvert_v1_ComponentStatus_To_v1alpha1_ComponentStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_Container_To_v1_Container(in *Container, out *v1.Container, s conversion.Scope) error {
+	out.ParentKind = in.ParentKind
+	out.ParentName = in.ParentName
+	out.Name = in.Name
+	out.Image = in.Image
+	return nil
+}
+
+// Convert_v1alpha1_Container_To_v1_Container is an autogenerated conversion function.
+func Convert_v1alpha1_Container_To_v1_Container(in *Container, out *v1.Container, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Container_To_v1_Container(in, out, s)
+}
+
+func autoConvert_v1_Container_To_v1alpha1_Container(in *v1.Container, out *Container, s conversion.Scope) error {
+	out.ParentKind = in.ParentKind
+	out.ParentName = in.ParentName
+	out.Name = in.Name
+	out.Image = in.Image
+	return nil
+}
+
+// Convert_v1_Container_To_v1alpha1_Container is an autogenerated conversion function.
+func Convert_v1_Container_To_v1alpha1_Container(in *v1.Container, out *Container, s conversion.Scope) error {
+	return autoConvert_v1_Container_To_v1alpha1_Container(in, out, s)
+}
+
+func autoConvert_v1alpha1_KubeMacPool_To_v1_KubeMacPool(in *KubeMacPool, out *v1.KubeMacPool, s conversion.Scope) error {
+	out.RangeStart = in.RangeStart
+	out.RangeEnd = in.RangeEnd
+	out.Placement = (*v1.Placement)(unsafe.Pointer(in.Placement))
+	out.Resources = *(*map[string]corev1.ResourceRequirements)(unsafe.Pointer(&in.Resources))
+	out.Image = in.Image
+	out.ProgressDeadline = (*metav1.Duration)(unsafe.Pointer(in.ProgressDeadline))
+	return nil
+}
+
+// Convert_v1alpha1_KubeMacPool_To_v1_KubeMacPool is an autogenerated conversion function.
+func Convert_v1alpha1_KubeMacPool_To_v1_KubeMacPool(in *KubeMacPool, out *v1.KubeMacPool, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KubeMacPool_To_v1_KubeMacPool(in, out, s)
+}
+
+func autoConvert_v1_KubeMacPool_To_v1alpha1_KubeMacPool(in *v1.KubeMacPool, out *KubeMacPool, s conversion.Scope) error {
+	out.RangeStart = in.RangeStart
+	out.RangeEnd = in.RangeEnd
+	out.Placement = (*Placement)(unsafe.Pointer(in.Placement))
+	out.Resources = *(*map[string]corev1.ResourceRequirements)(unsafe.Pointer(&in.Resources))
+	out.Image = in.Image
+	out.ProgressDeadline = (*metav1.Duration)(unsafe.Pointer(in.ProgressDeadline))
+	return nil
+}
+
+// Convert_v1_KubeMacPool_To_v1alpha1_KubeMacPool is an autogenerated conversion function.
+func Convert_v1_KubeMacPool_To_v1alpha1_KubeMacPool(in *v1.KubeMacPool, out *KubeMacPool, s conversion.Scope) error {
+	return autoConvert_v1_KubeMacPool_To_v1alpha1_KubeMacPool(in, out, s)
+}
+
+func autoConvert_v1alpha1_LinuxBridge_To_v1_LinuxBridge(in *LinuxBridge, out *v1.LinuxBridge, s conversion.Scope) error {
+	out.Placement = (*v1.Placement)(unsafe.Pointer(in.Placement))
+	out.Resources = *(*map[string]corev1.ResourceRequirements)(unsafe.Pointer(&in.Resources))
+	out.Image = in.Image
+	out.MarkerImage = in.MarkerImage
+	out.ProgressDeadline = (*metav1.Duration)(unsafe.Pointer(in.ProgressDeadline))
+	return nil
+}
+
+// Convert_v1alpha1_LinuxBridge_To_v1_LinuxBridge is an autogenerated conversion function.
+func Convert_v1alpha1_LinuxBridge_To_v1_LinuxBridge(in *LinuxBridge, out *v1.LinuxBridge, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LinuxBridge_To_v1_LinuxBridge(in, out, s)
+}
+
+func autoConvert_v1_LinuxBridge_To_v1alpha1_LinuxBridge(in *v1.LinuxBridge, out *LinuxBridge, s conversion.Scope) error {
+	out.Placement = (*Placement)(unsafe.Pointer(in.Placement))
+	out.Resources = *(*map[string]corev1.ResourceRequirements)(unsafe.Pointer(&in.Resources))
+	out.Image = in.Image
+	out.MarkerImage = in.MarkerImage
+	out.ProgressDeadline = (*metav1.Duration)(unsafe.Pointer(in.ProgressDeadline))
+	return nil
+}
+
+// Convert_v1_LinuxBridge_To_v1alpha1_LinuxBridge is an autogenerated conversion function.
+func Convert_v1_LinuxBridge_To_v1alpha1_LinuxBridge(in *v1.LinuxBridge, out *LinuxBridge, s conversion.Scope) error {
+	return autoConvert_v1_LinuxBridge_To_v1alpha1_LinuxBridge(in, out, s)
+}
+
+func autoConvert_v1alpha1_Monitoring_To_v1_Monitoring(in *Monitoring, out *v1.Monitoring, s conversion.Scope) error {
+	return nil
+}
+
+// Convert_v1alpha1_Monitoring_To_v1_Monitoring is an autogenerated conversion function.
+func Convert_v1alpha1_Monitoring_To_v1_Monitoring(in *Monitoring, out *v1.Monitoring, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Monitoring_To_v1_Monitoring(in, out, s)
+}
+
+func autoConvert_v1_Monitoring_To_v1alpha1_Monitoring(in *v1.Monitoring, out *Monitoring, s conversion.Scope) error {
+	return nil
+}
+
+// Convert_v1_Monitoring_To_v1alpha1_Monitoring is an autogenerated conversion function.
+func Convert_v1_Monitoring_To_v1alpha1_Monitoring(in *v1.Monitoring, out *Monitoring, s conversion.Scope) error {
+	return autoConvert_v1_Monitoring_To_v1alpha1_Monitoring(in, out, s)
+}
+
+func autoConvert_v1alpha1_Multus_To_v1_Multus(in *Multus, out *v1.Multus, s conversion.Scope) error {
+	out.Placement = (*v1.Placement)(unsafe.Pointer(in.Placement))
+	out.Resources = *(*map[string]corev1.ResourceRequirements)(unsafe.Pointer(&in.Resources))
+	out.Image = in.Image
+	out.ProgressDeadline = (*metav1.Duration)(unsafe.Pointer(in.ProgressDeadline))
+	return nil
+}
+
+// Convert_v1alpha1_Multus_To_v1_Multus is an autogenerated conversion function.
+func Convert_v1alpha1_Multus_To_v1_Multus(in *Multus, out *v1.Multus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Multus_To_v1_Multus(in, out, s)
+}
+
+func autoConvert_v1_Multus_To_v1alpha1_Multus(in *v1.Multus, out *Multus, s conversion.Scope) error {
+	out.Placement = (*Placement)(unsafe.Pointer(in.Placement))
+	out.Resources = *(*map[string]corev1.ResourceRequirements)(unsafe.Pointer(&in.Resources))
+	out.Image = in.Image
+	out.ProgressDeadline = (*metav1.Duration)(unsafe.Pointer(in.ProgressDeadline))
+	return nil
+}
+
+// Convert_v1_Multus_To_v1alpha1_Multus is an autogenerated conversion function.
+func Convert_v1_Multus_To_v1alpha1_Multus(in *v1.Multus, out *Multus, s conversion.Scope) error {
+	return autoConvert_v1_Multus_To_v1alpha1_Multus(in, out, s)
+}
+
+func autoConvert_v1alpha1_NMState_To_v1_NMState(in *NMState, out *v1.NMState, s conversion.Scope) error {
+	out.Placement = (*v1.Placement)(unsafe.Pointer(in.Placement))
+	out.Resources = *(*map[string]corev1.ResourceRequirements)(unsafe.Pointer(&in.Resources))
+	out.Image = in.Image
+	out.ProgressDeadline = (*metav1.Duration)(unsafe.Pointer(in.ProgressDeadline))
+	return nil
+}
+
+// Convert_v1alpha1_NMState_To_v1_NMState is an autogenerated conversion function.
+func Convert_v1alpha1_NMState_To_v1_NMState(in *NMState, out *v1.NMState, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NMState_To_v1_NMState(in, out, s)
+}
+
+func autoConvert_v1_NMState_To_v1alpha1_NMState(in *v1.NMState, out *NMState, s conversion.Scope) error {
+	out.Placement = (*Placement)(unsafe.Pointer(in.Placement))
+	out.Resources = *(*map[string]corev1.ResourceRequirements)(unsafe.Pointer(&in.Resources))
+	out.Image = in.Image
+	out.ProgressDeadline = (*metav1.Duration)(unsafe.Pointer(in.ProgressDeadline))
+	return nil
+}
+
+// Convert_v1_NMState_To_v1alpha1_NMState is an autogenerated conversion function.
+func Convert_v1_NMState_To_v1alpha1_NMState(in *v1.NMState, out *NMState, s conversion.Scope) error {
+	return autoConvert_v1_NMState_To_v1alpha1_NMState(in, out, s)
+}
+
+func autoConvert_v1alpha1_NetworkAddonsConfig_To_v1_NetworkAddonsConfig(in *NetworkAddonsConfig, out *v1.NetworkAddonsConfig, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_NetworkAddonsConfigSpec_To_v1_NetworkAddonsConfigSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_NetworkAddonsConfigStatus_To_v1_NetworkAddonsConfigStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_NetworkAddonsConfig_To_v1_NetworkAddonsConfig is an autogenerated conversion function.
+func Convert_v1alpha1_NetworkAddonsConfig_To_v1_NetworkAddonsConfig(in *NetworkAddonsConfig, out *v1.NetworkAddonsConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NetworkAddonsConfig_To_v1_NetworkAddonsConfig(in, out, s)
+}
+
+func autoConvert_v1_NetworkAddonsConfig_To_v1alpha1_NetworkAddonsConfig(in *v1.NetworkAddonsConfig, out *NetworkAddonsConfig, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_NetworkAddonsConfigSpec_To_v1alpha1_NetworkAddonsConfigSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1_NetworkAddonsConfigStatus_To_v1alpha1_NetworkAddonsConfigStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_NetworkAddonsConfig_To_v1alpha1_NetworkAddonsConfig is an autogenerated conversion function.
+func Convert_v1_NetworkAddonsConfig_To_v1alpha1_NetworkAddonsConfig(in *v1.NetworkAddonsConfig, out *NetworkAddonsConfig, s conversion.Scope) error {
+	return autoConvert_v1_NetworkAddonsConfig_To_v1alpha1_NetworkAddonsConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_NetworkAddonsConfigList_To_v1_NetworkAddonsConfigList(in *NetworkAddonsConfigList, out *v1.NetworkAddonsConfigList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]v1.NetworkAddonsConfig)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_NetworkAddonsConfigList_To_v1_NetworkAddonsConfigList is an autogenerated conversion function.
+func Convert_v1alpha1_NetworkAddonsConfigList_To_v1_NetworkAddonsConfigList(in *NetworkAddonsConfigList, out *v1.NetworkAddonsConfigList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NetworkAddonsConfigList_To_v1_NetworkAddonsConfigList(in, out, s)
+}
+
+func autoConvert_v1_NetworkAddonsConfigList_To_v1alpha1_NetworkAddonsConfigList(in *v1.NetworkAddonsConfigList, out *NetworkAddonsConfigList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]NetworkAddonsConfig)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1_NetworkAddonsConfigList_To_v1alpha1_NetworkAddonsConfigList is an autogenerated conversion function.
+func Convert_v1_NetworkAddonsConfigList_To_v1alpha1_NetworkAddonsConfigList(in *v1.NetworkAddonsConfigList, out *NetworkAddonsConfigList, s conversion.Scope) error {
+	return autoConvert_v1_NetworkAddonsConfigList_To_v1alpha1_NetworkAddonsConfigList(in, out, s)
+}
+
+func autoConvert_v1alpha1_NetworkAddonsConfigSpec_To_v1_NetworkAddonsConfigSpec(in *NetworkAddonsConfigSpec, out *v1.NetworkAddonsConfigSpec, s conversion.Scope) error {
+	out.Multus = (*v1.Multus)(unsafe.Pointer(in.Multus))
+	out.LinuxBridge = (*v1.LinuxBridge)(unsafe.Pointer(in.LinuxBridge))
+	out.Ovs = (*v1.Ovs)(unsafe.Pointer(in.Ovs))
+	out.KubeMacPool = (*v1.KubeMacPool)(unsafe.Pointer(in.KubeMacPool))
+	out.ImagePullPolicy = corev1.PullPolicy(in.ImagePullPolicy)
+	out.NMState = (*v1.NMState)(unsafe.Pointer(in.NMState))
+	out.Placement = (*v1.Placement)(unsafe.Pointer(in.Placement))
+	out.DryRun = in.DryRun
+	out.Patches = *(*[]v1.Patch)(unsafe.Pointer(&in.Patches))
+	out.Monitoring = (*v1.Monitoring)(unsafe.Pointer(in.Monitoring))
+	return nil
+}
+
+// Convert_v1alpha1_NetworkAddonsConfigSpec_To_v1_NetworkAddonsConfigSpec is an autogenerated conversion function.
+func Convert_v1alpha1_NetworkAddonsConfigSpec_To_v1_NetworkAddonsConfigSpec(in *NetworkAddonsConfigSpec, out *v1.NetworkAddonsConfigSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NetworkAddonsConfigSpec_To_v1_NetworkAddonsConfigSpec(in, out, s)
+}
+
+func autoConvert_v1_NetworkAddonsConfigSpec_To_v1alpha1_NetworkAddonsConfigSpec(in *v1.NetworkAddonsConfigSpec, out *NetworkAddonsConfigSpec, s conversion.Scope) error {
+	out.Multus = (*Multus)(unsafe.Pointer(in.Multus))
+	out.LinuxBridge = (*LinuxBridge)(unsafe.Pointer(in.LinuxBridge))
+	out.Ovs = (*Ovs)(unsafe.Pointer(in.Ovs))
+	out.KubeMacPool = (*KubeMacPool)(unsafe.Pointer(in.KubeMacPool))
+	out.ImagePullPolicy = corev1.PullPolicy(in.ImagePullPolicy)
+	out.NMState = (*NMState)(unsafe.Pointer(in.NMState))
+	out.Placement = (*Placement)(unsafe.Pointer(in.Placement))
+	out.DryRun = in.DryRun
+	out.Patches = *(*[]Patch)(unsafe.Pointer(&in.Patches))
+	out.Monitoring = (*Monitoring)(unsafe.Pointer(in.Monitoring))
+	return nil
+}
+
+// Convert_v1_NetworkAddonsConfigSpec_To_v1alpha1_NetworkAddonsConfigSpec is an autogenerated conversion function.
+func Convert_v1_NetworkAddonsConfigSpec_To_v1alpha1_NetworkAddonsConfigSpec(in *v1.NetworkAddonsConfigSpec, out *NetworkAddonsConfigSpec, s conversion.Scope) error {
+	return autoConvert_v1_NetworkAddonsConfigSpec_To_v1alpha1_NetworkAddonsConfigSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_NetworkAddonsConfigStatus_To_v1_NetworkAddonsConfigStatus(in *NetworkAddonsConfigStatus, out *v1.NetworkAddonsConfigStatus, s conversion.Scope) error {
+	out.OperatorVersion = in.OperatorVersion
+	out.ObservedVersion = in.ObservedVersion
+	out.TargetVersion = in.TargetVersion
+	out.Conditions = *(*[]conditionsv1.Condition)(unsafe.Pointer(&in.Conditions))
+	out.Containers = *(*[]v1.Container)(unsafe.Pointer(&in.Containers))
+	out.Patches = *(*[]v1.AppliedPatch)(unsafe.Pointer(&in.Patches))
+	out.Components = *(*[]v1.ComponentStatus)(unsafe.Pointer(&in.Components))
+	return nil
+}
+
+// Convert_v1alpha1_NetworkAddonsConfigStatus_To_v1_NetworkAddonsConfigStatus is an autogenerated conversion function.
+func Convert_v1alpha1_NetworkAddonsConfigStatus_To_v1_NetworkAddonsConfigStatus(in *NetworkAddonsConfigStatus, out *v1.NetworkAddonsConfigStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NetworkAddonsConfigStatus_To_v1_NetworkAddonsConfigStatus(in, out, s)
+}
+
+func autoConvert_v1_NetworkAddonsConfigStatus_To_v1alpha1_NetworkAddonsConfigStatus(in *v1.NetworkAddonsConfigStatus, out *NetworkAddonsConfigStatus, s conversion.Scope) error {
+	out.OperatorVersion = in.OperatorVersion
+	out.ObservedVersion = in.ObservedVersion
+	out.TargetVersion = in.TargetVersion
+	out.Conditions = *(*[]conditionsv1.Condition)(unsafe.Pointer(&in.Conditions))
+	out.Containers = *(*[]Container)(unsafe.Pointer(&in.Containers))
+	out.Patches = *(*[]AppliedPatch)(unsafe.Pointer(&in.Patches))
+	out.Components = *(*[]ComponentStatus)(unsafe.Pointer(&in.Components))
+	return nil
+}
+
+// Convert_v1_NetworkAddonsConfigStatus_To_v1alpha1_NetworkAddonsConfigStatus is an autogenerated conversion function.
+func Convert_v1_NetworkAddonsConfigStatus_To_v1alpha1_NetworkAddonsConfigStatus(in *v1.NetworkAddonsConfigStatus, out *NetworkAddonsConfigStatus, s conversion.Scope) error {
+	return autoConvert_v1_NetworkAddonsConfigStatus_To_v1alpha1_NetworkAddonsConfigStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_Ovs_To_v1_Ovs(in *Ovs, out *v1.Ovs, s conversion.Scope) error {
+	out.Placement = (*v1.Placement)(unsafe.Pointer(in.Placement))
+	out.Resources = *(*map[string]corev1.ResourceRequirements)(unsafe.Pointer(&in.Resources))
+	out.Image = in.Image
+	out.MarkerImage = in.MarkerImage
+	out.ProgressDeadline = (*metav1.Duration)(unsafe.Pointer(in.ProgressDeadline))
+	return nil
+}
+
+// Convert_v1alpha1_Ovs_To_v1_Ovs is an autogenerated conversion function.
+func Convert_v1alpha1_Ovs_To_v1_Ovs(in *Ovs, out *v1.Ovs, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Ovs_To_v1_Ovs(in, out, s)
+}
+
+func autoConvert_v1_Ovs_To_v1alpha1_Ovs(in *v1.Ovs, out *Ovs, s conversion.Scope) error {
+	out.Placement = (*Placement)(unsafe.Pointer(in.Placement))
+	out.Resources = *(*map[string]corev1.ResourceRequirements)(unsafe.Pointer(&in.Resources))
+	out.Image = in.Image
+	out.MarkerImage = in.MarkerImage
+	out.ProgressDeadline = (*metav1.Duration)(unsafe.Pointer(in.ProgressDeadline))
+	return nil
+}
+
+// Convert_v1_Ovs_To_v1alpha1_Ovs is an autogenerated conversion function.
+func Convert_v1_Ovs_To_v1alpha1_Ovs(in *v1.Ovs, out *Ovs, s conversion.Scope) error {
+	return autoConvert_v1_Ovs_To_v1alpha1_Ovs(in, out, s)
+}
+
+func autoConvert_v1alpha1_Patch_To_v1_Patch(in *Patch, out *v1.Patch, s conversion.Scope) error {
+	if err := Convert_v1alpha1_PatchTarget_To_v1_PatchTarget(&in.Target, &out.Target, s); err != nil {
+		return err
+	}
+	out.Type = v1.PatchType(in.Type)
+	out.Patch = in.Patch
+	return nil
+}
+
+// Convert_v1alpha1_Patch_To_v1_Patch is an autogenerated conversion function.
+func Convert_v1alpha1_Patch_To_v1_Patch(in *Patch, out *v1.Patch, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Patch_To_v1_Patch(in, out, s)
+}
+
+func autoConvert_v1_Patch_To_v1alpha1_Patch(in *v1.Patch, out *Patch, s conversion.Scope) error {
+	if err := Convert_v1_PatchTarget_To_v1alpha1_PatchTarget(&in.Target, &out.Target, s); err != nil {
+		return err
+	}
+	out.Type = PatchType(in.Type)
+	out.Patch = in.Patch
+	return nil
+}
+
+// Convert_v1_Patch_To_v1alpha1_Patch is an autogenerated conversion function.
+func Convert_v1_Patch_To_v1alpha1_Patch(in *v1.Patch, out *Patch, s conversion.Scope) error {
+	return autoConvert_v1_Patch_To_v1alpha1_Patch(in, out, s)
+}
+
+func autoConvert_v1alpha1_PatchTarget_To_v1_PatchTarget(in *PatchTarget, out *v1.PatchTarget, s conversion.Scope) error {
+	out.APIVersion = in.APIVersion
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha1_PatchTarget_To_v1_PatchTarget is an autogenerated conversion function.
+func Convert_v1alpha1_PatchTarget_To_v1_PatchTarget(in *PatchTarget, out *v1.PatchTarget, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PatchTarget_To_v1_PatchTarget(in, out, s)
+}
+
+func autoConvert_v1_PatchTarget_To_v1alpha1_PatchTarget(in *v1.PatchTarget, out *PatchTarget, s conversion.Scope) error {
+	out.APIVersion = in.APIVersion
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1_PatchTarget_To_v1alpha1_PatchTarget is an autogenerated conversion function.
+func Convert_v1_PatchTarget_To_v1alpha1_PatchTarget(in *v1.PatchTarget, out *PatchTarget, s conversion.Scope) error {
+	return autoConvert_v1_PatchTarget_To_v1alpha1_PatchTarget(in, out, s)
+}
+
+func autoConvert_v1alpha1_Placement_To_v1_Placement(in *Placement, out *v1.Placement, s conversion.Scope) error {
+	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
+	out.Affinity = (*corev1.Affinity)(unsafe.Pointer(in.Affinity))
+	out.Tolerations = *(*[]corev1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	return nil
+}
+
+// Convert_v1alpha1_Placement_To_v1_Placement is an autogenerated conversion function.
+func Convert_v1alpha1_Placement_To_v1_Placement(in *Placement, out *v1.Placement, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Placement_To_v1_Placement(in, out, s)
+}
+
+func autoConvert_v1_Placement_To_v1alpha1_Placement(in *v1.Placement, out *Placement, s conversion.Scope) error {
+	out.NodeSelector = *(*map[string]string)(unsafe.Pointer(&in.NodeSelector))
+	out.Affinity = (*corev1.Affinity)(unsafe.Pointer(in.Affinity))
+	out.Tolerations = *(*[]corev1.Toleration)(unsafe.Pointer(&in.Tolerations))
+	return nil
+}
+
+// Convert_v1_Placement_To_v1alpha1_Placement is an autogenerated conversion function.
+func Convert_v1_Placement_To_v1alpha1_Placement(in *v1.Placement, out *Placement, s conversion.Scope) error {
+	return autoConvert_v1_Placement_To_v1alpha1_Placement(in, out, s)
+}
+
+func autoConvert_v1alpha1_WorkloadStatus_To_v1_WorkloadStatus(in *WorkloadStatus, out *v1.WorkloadStatus, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.Desired = in.Desired
+	out.Ready = in.Ready
+	return nil
+}
+
+// Convert_v1alpha1_WorkloadStatus_To_v1_WorkloadStatus is an autogenerated conversion function.
+func Convert_v1alpha1_WorkloadStatus_To_v1_WorkloadStatus(in *WorkloadStatus, out *v1.WorkloadStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_WorkloadStatus_To_v1_WorkloadStatus(in, out, s)
+}
+
+func autoConvert_v1_WorkloadStatus_To_v1alpha1_WorkloadStatus(in *v1.WorkloadStatus, out *WorkloadStatus, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.Desired = in.Desired
+	out.Ready = in.Ready
+	return nil
+}
+
+// Convert_v1_WorkloadStatus_To_v1alpha1_WorkloadStatus is an autogenerated conversion function.
+func Convert_v1_WorkloadStatus_To_v1alpha1_WorkloadStatus(in *v1.WorkloadStatus, out *WorkloadStatus, s conversion.Scope) error {
+	return autoConvert_v1_WorkloadStatus_To_v1alpha1_WorkloadStatus(in, out, s)
+}
@@ -6,20 +6,32 @@ import (
 	"log"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/metrics"
 )
 
 // ApplyObject applies the desired object against the apiserver,
-// merging it with any existing objects if already present.
-func ApplyObject(ctx context.Context, client k8sclient.Client, obj *uns.Unstructured) error {
+// merging it with any existing objects if already present. Only fields
+// rendered by the operator are owned by it, see MergeObjectForUpdate.
+// Objects of known kinds which cannot be updated because of a changed
+// immutable field are deleted and an error satisfying IsRecreatePending is
+// returned, they are created once they are gone by a later call. The same
+// error is returned while the existing object is still being deleted.
+// Creation, update and recreation of the object are reported to the
+// recorder if one is given. It returns whether the object was changed on
+// the apiserver.
+func ApplyObject(ctx context.Context, client k8sclient.Client, recorder record.EventRecorder, obj *uns.Unstructured) (bool, error) {
 	name := obj.GetName()
 	namespace := obj.GetNamespace()
 	if name == "" {
-		return errors.Errorf("object %s has no name", obj.GroupVersionKind().String())
+		return false, errors.Errorf("object %s has no name", obj.GroupVersionKind().String())
 	}
 	gvk := obj.GroupVersionKind()
 	// used for logging and errors
@@ -27,7 +39,11 @@ func ApplyObject(ctx context.Context, client k8sclient.Client, obj *uns.Unstruct
 	log.Printf("reconciling %s", objDesc)
 
 	if err := IsObjectSupported(obj); err != nil {
-		return errors.Wrapf(err, "object %s unsupported", objDesc)
+		return false, errors.Wrapf(err, "object %s unsupported", objDesc)
+	}
+
+	if err := SetLastApplied(obj); err != nil {
+		return false, errors.Wrapf(err, "could not record last applied configuration of %s", objDesc)
 	}
 
 	// Get existing
@@ -39,26 +55,56 @@ func ApplyObject(ctx context.Context, client k8sclient.Client, obj *uns.Unstruct
 		log.Printf("does not exist, creating %s", objDesc)
 		err := client.Create(ctx, obj)
 		if err != nil {
-			return errors.Wrapf(err, "could not create %s", objDesc)
+			return false, errors.Wrapf(err, "could not create %s", objDesc)
 		}
 		log.Printf("successfully created %s", objDesc)
-		return nil
+		metrics.ReportApplied(gvk.Kind, metrics.ActionCreated)
+		if recorder != nil {
+			recorder.Eventf(obj, corev1.EventTypeNormal, "Created", "%s %s was created", gvk.Kind, name)
+		}
+		return true, nil
 	}
 	if err != nil {
-		return errors.Wrapf(err, "could not retrieve existing %s", objDesc)
+		return false, errors.Wrapf(err, "could not retrieve existing %s", objDesc)
+	}
+	if existing.GetDeletionTimestamp() != nil {
+		return false, &recreatePendingError{objDesc: objDesc}
 	}
 
 	// Merge the desired object with what actually exists
-	if err := MergeObjectForUpdate(existing, obj); err != nil {
-		return errors.Wrapf(err, "could not merge object %s with existing", objDesc)
+	merged, err := MergeObjectForUpdate(existing, obj)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not merge object %s with existing", objDesc)
 	}
-	if !equality.Semantic.DeepEqual(existing, obj) {
-		if err := client.Update(ctx, obj); err != nil {
-			return errors.Wrapf(err, "could not update object %s", objDesc)
+	if !equality.Semantic.DeepEqual(existing, merged) {
+		// Nothing was changed in the desired object since it was last applied, so somebody else
+		// must have modified fields owned by the operator
+		drifted := existing.GetAnnotations()[LastAppliedAnnotation] == obj.GetAnnotations()[LastAppliedAnnotation]
+
+		err := client.Update(ctx, merged)
+		if IsImmutableFieldError(err) {
+			err = recreateObject(ctx, client, recorder, existing, obj, err)
+			if IsRecreatePending(err) {
+				metrics.ReportApplied(gvk.Kind, metrics.ActionRecreated)
+				return true, err
+			}
+		}
+		if err != nil {
+			return false, errors.Wrapf(err, "could not update object %s", objDesc)
 		} else {
 			log.Print("update was successful")
 		}
+		metrics.ReportApplied(gvk.Kind, metrics.ActionUpdated)
+
+		if drifted && recorder != nil {
+			log.Printf("%s was modified, restored its owned fields", objDesc)
+			recorder.Eventf(merged, corev1.EventTypeWarning, "Drifted", "%s %s was modified, fields owned by the operator were restored", gvk.Kind, name)
+		} else if recorder != nil {
+			recorder.Eventf(merged, corev1.EventTypeNormal, "Updated", "%s %s was updated", gvk.Kind, name)
+		}
+		return true, nil
 	}
 
-	return nil
+	metrics.ReportApplied(gvk.Kind, metrics.ActionUnchanged)
+	return false, nil
 }
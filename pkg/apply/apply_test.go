@@ -10,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -26,6 +27,8 @@ var _ = Describe("ApplyObject", func() {
 		})
 
 		Context("and new object is applied", func() {
+			var recorder *record.FakeRecorder
+			var changed bool
 			object := k8s.UnstructuredFromYaml(`
 apiVersion: apps/v1
 kind: Deployment
@@ -33,13 +36,25 @@ metadata:
   name: d1`)
 
 			BeforeEach(func() {
-				err := apply.ApplyObject(context.Background(), client, object)
+				var err error
+				recorder = record.NewFakeRecorder(10)
+				changed, err = apply.ApplyObject(context.Background(), client, recorder, object.DeepCopy())
 				Expect(err).ToNot(HaveOccurred())
 			})
 
-			It("should create new object", func() {
+			It("should create new object and record an event", func() {
 				err := client.Get(context.Background(), types.NamespacedName{Name: "d1"}, &appsv1.Deployment{})
 				Expect(err).ToNot(HaveOccurred())
+				Expect(changed).To(BeTrue())
+				Expect(recorder.Events).To(Receive(Equal("Normal Created Deployment d1 was created")))
+			})
+
+			Context("and it is applied again", func() {
+				It("should report that nothing was changed", func() {
+					changed, err := apply.ApplyObject(context.Background(), client, nil, object.DeepCopy())
+					Expect(err).ToNot(HaveOccurred())
+					Expect(changed).To(BeFalse())
+				})
 			})
 		})
 	})
@@ -77,7 +92,7 @@ metadata:
 
 			It("should succesfully merge", func() {
 				By("Apllying object to server")
-				err := apply.ApplyObject(context.Background(), client, object)
+				_, err := apply.ApplyObject(context.Background(), client, nil, object)
 				Expect(err).ToNot(HaveOccurred())
 
 				By("Finding the object in server")
@@ -93,6 +108,40 @@ metadata:
 			})
 		})
 
+		Context("and it was modified by somebody else after it was applied", func() {
+			object := k8s.UnstructuredFromYaml(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: d1
+  annotations:
+    foo: A`)
+
+			It("should restore it and record an event", func() {
+				By("Applying object to server")
+				_, err := apply.ApplyObject(context.Background(), client, nil, object.DeepCopy())
+				Expect(err).ToNot(HaveOccurred())
+
+				By("Modifying the object on server")
+				found := &appsv1.Deployment{}
+				err = client.Get(context.Background(), types.NamespacedName{Name: "d1"}, found)
+				Expect(err).ToNot(HaveOccurred())
+				found.Annotations["foo"] = "B"
+				err = client.Update(context.Background(), found)
+				Expect(err).ToNot(HaveOccurred())
+
+				By("Applying the same object again")
+				recorder := record.NewFakeRecorder(10)
+				_, err = apply.ApplyObject(context.Background(), client, recorder, object.DeepCopy())
+				Expect(err).ToNot(HaveOccurred())
+
+				err = client.Get(context.Background(), types.NamespacedName{Name: "d1"}, found)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found.Annotations).To(HaveKeyWithValue("foo", "A"))
+				Expect(recorder.Events).To(Receive(ContainSubstring("Drifted")))
+			})
+		})
+
 		Context("and is given the same object with different annotations", func() {
 			found := &appsv1.Deployment{}
 			object := k8s.UnstructuredFromYaml(`
@@ -105,7 +154,8 @@ metadata:
 
 			It("should have new annotations", func() {
 				By("Apllying object to server")
-				err := apply.ApplyObject(context.Background(), client, object)
+				recorder := record.NewFakeRecorder(10)
+				_, err := apply.ApplyObject(context.Background(), client, recorder, object)
 				Expect(err).ToNot(HaveOccurred())
 
 				By("Finding the object in server")
@@ -114,6 +164,9 @@ metadata:
 
 				By("Having the updated annotations")
 				Expect(found.GetAnnotations()).To(Equal(object.GetAnnotations()))
+
+				By("Recording an event")
+				Expect(recorder.Events).To(Receive(Equal("Normal Updated Deployment d1 was updated")))
 			})
 		})
 	})
@@ -0,0 +1,77 @@
+package apply
+
+import (
+	"fmt"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// InventoryItem identifies an object applied by the operator. Version is kept so the object can be
+// addressed later, but it is ignored when objects are compared, since a template may switch to a
+// newer version of the same API
+type InventoryItem struct {
+	Group     string `json:"group"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Components which rendered the object. An object shared by several components, such as
+	// their namespace, lists all of them
+	Components []string `json:"components,omitempty"`
+}
+
+// Inventory lists identities of given objects
+func Inventory(objs []*uns.Unstructured) []InventoryItem {
+	inventory := []InventoryItem{}
+	for _, obj := range objs {
+		inventory = append(inventory, inventoryItemOf(obj))
+	}
+	return inventory
+}
+
+// Obsolete returns objects listed in the inventory which are not among the desired objects anymore.
+// Returned objects carry only their identity, which is sufficient to delete them
+func Obsolete(inventory []InventoryItem, desired []*uns.Unstructured) []*uns.Unstructured {
+	desiredKeys := map[string]bool{}
+	for _, obj := range desired {
+		desiredKeys[ObjectKey(obj)] = true
+	}
+
+	obsolete := []*uns.Unstructured{}
+	for _, item := range inventory {
+		if !desiredKeys[item.key()] {
+			obsolete = append(obsolete, item.Object())
+		}
+	}
+	return obsolete
+}
+
+// ObjectKey identifies an object regardless of the version of its API
+func ObjectKey(obj *uns.Unstructured) string {
+	return inventoryItemOf(obj).key()
+}
+
+// Object returns an empty object with the identity of the item
+func (item InventoryItem) Object() *uns.Unstructured {
+	obj := &uns.Unstructured{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: item.Group, Version: item.Version, Kind: item.Kind})
+	obj.SetNamespace(item.Namespace)
+	obj.SetName(item.Name)
+	return obj
+}
+
+func (item InventoryItem) key() string {
+	return fmt.Sprintf("%s/%s/%s/%s", item.Group, item.Kind, item.Namespace, item.Name)
+}
+
+func inventoryItemOf(obj *uns.Unstructured) InventoryItem {
+	gvk := obj.GroupVersionKind()
+	return InventoryItem{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
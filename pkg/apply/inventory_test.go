@@ -0,0 +1,53 @@
+package apply_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/util/k8s"
+)
+
+var _ = Describe("Inventory", func() {
+	daemonSet := k8s.UnstructuredFromYaml(`
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: ovs-cni
+  namespace: cluster-network-addons`)
+	clusterRole := k8s.UnstructuredFromYaml(`
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: ovs-cni-marker-cr`)
+
+	It("should list identities of objects", func() {
+		Expect(apply.Inventory([]*uns.Unstructured{daemonSet, clusterRole})).To(Equal([]apply.InventoryItem{
+			{Group: "apps", Version: "v1", Kind: "DaemonSet", Namespace: "cluster-network-addons", Name: "ovs-cni"},
+			{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole", Name: "ovs-cni-marker-cr"},
+		}))
+	})
+
+	Describe("Obsolete", func() {
+		inventory := []apply.InventoryItem{
+			{Group: "apps", Version: "v1", Kind: "DaemonSet", Namespace: "cluster-network-addons", Name: "ovs-cni"},
+			{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "ClusterRole", Name: "ovs-cni-marker-cr"},
+			{Group: "apps", Version: "v1", Kind: "DaemonSet", Namespace: "cluster-network-addons", Name: "ovs-cni-amd64"},
+		}
+
+		It("should return objects which are not desired anymore", func() {
+			obsolete := apply.Obsolete(inventory, []*uns.Unstructured{daemonSet, clusterRole})
+			Expect(obsolete).To(HaveLen(1))
+			Expect(obsolete[0].GetKind()).To(Equal("DaemonSet"))
+			Expect(obsolete[0].GetAPIVersion()).To(Equal("apps/v1"))
+			Expect(obsolete[0].GetNamespace()).To(Equal("cluster-network-addons"))
+			Expect(obsolete[0].GetName()).To(Equal("ovs-cni-amd64"))
+		})
+
+		It("should return nothing when the inventory is empty", func() {
+			Expect(apply.Obsolete(nil, []*uns.Unstructured{daemonSet})).To(BeEmpty())
+		})
+	})
+})
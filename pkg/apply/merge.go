@@ -4,146 +4,116 @@ import (
 	"github.com/pkg/errors"
 
 	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
 )
 
-// MergeMetadataForUpdate merges the read-only fields of metadata.
-// This is to be able to do a a meaningful comparison in apply,
-// since objects created on runtime do not have these fields populated.
-func MergeMetadataForUpdate(current, updated *unstructured.Unstructured) error {
-	updated.SetCreationTimestamp(current.GetCreationTimestamp())
-	updated.SetSelfLink(current.GetSelfLink())
-	updated.SetGeneration(current.GetGeneration())
-	updated.SetUID(current.GetUID())
-	updated.SetResourceVersion(current.GetResourceVersion())
-
-	mergeAnnotations(current, updated)
-	mergeLabels(current, updated)
-
-	return nil
-}
-
-// MergeObjectForUpdate prepares a "desired" object to be updated.
-// Some objects, such as Deployments and Services require
-// some semantic-aware updates
-func MergeObjectForUpdate(current, updated *unstructured.Unstructured) error {
-	if err := MergeDeploymentForUpdate(current, updated); err != nil {
-		return err
+// LastAppliedAnnotation keeps the object as it was last applied by the operator. It
+// is used to find fields which were dropped from templates since, so they can be
+// removed without touching fields set by anybody else.
+const LastAppliedAnnotation = "networkaddonsoperator.network.kubevirt.io/last-applied-configuration"
+
+// SetLastApplied stores the desired object in its own annotation,
+// it has to be called before the object is created or merged.
+func SetLastApplied(obj *unstructured.Unstructured) error {
+	lastApplied := obj.DeepCopy()
+	annotations := lastApplied.GetAnnotations()
+	if _, found := annotations[LastAppliedAnnotation]; found {
+		delete(annotations, LastAppliedAnnotation)
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		lastApplied.SetAnnotations(annotations)
 	}
 
-	if err := MergeServiceForUpdate(current, updated); err != nil {
-		return err
+	data, err := lastApplied.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize last applied configuration")
 	}
 
-	if err := MergeServiceAccountForUpdate(current, updated); err != nil {
-		return err
+	annotations = obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
 	}
-
-	// For all object types, merge metadata.
-	// Run this last, in case any of the more specific merge logic has
-	// changed "updated"
-	MergeMetadataForUpdate(current, updated)
+	annotations[LastAppliedAnnotation] = string(data)
+	obj.SetAnnotations(annotations)
 
 	return nil
 }
 
-const (
-	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
-)
-
-// MergeDeploymentForUpdate updates Deployment objects.
-// We merge annotations, keeping ours except the Deployment Revision annotation.
-func MergeDeploymentForUpdate(current, updated *unstructured.Unstructured) error {
-	gvk := updated.GroupVersionKind()
-	if gvk.Group == "apps" && gvk.Kind == "Deployment" {
-
-		// Copy over the revision annotation from current up to updated
-		// otherwise, updated would win, and this annotation is "special" and
-		// needs to be preserved
-		curAnnotations := current.GetAnnotations()
-		updatedAnnotations := updated.GetAnnotations()
-		if updatedAnnotations == nil {
-			updatedAnnotations = map[string]string{}
-		}
-
-		anno, ok := curAnnotations[deploymentRevisionAnnotation]
-		if ok {
-			updatedAnnotations[deploymentRevisionAnnotation] = anno
-		}
-
-		updated.SetAnnotations(updatedAnnotations)
+// MergeObjectForUpdate does a three-way merge of the current object with the
+// updated one, using the last applied configuration stored in the current object.
+// Fields of the updated object win, fields which were dropped from it since the
+// last apply are removed and all other fields, e.g. those populated by the
+// apiserver or set by other controllers, are kept as they are.
+// Lists of built-in kinds are merged using their patch strategy, such as by name
+// of containers. Lists of other kinds are replaced.
+func MergeObjectForUpdate(current, updated *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	original := []byte(current.GetAnnotations()[LastAppliedAnnotation])
+
+	modified, err := updated.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize updated object")
 	}
 
-	return nil
-}
-
-// MergeServiceForUpdate ensures the clusterip is never written to
-func MergeServiceForUpdate(current, updated *unstructured.Unstructured) error {
-	gvk := updated.GroupVersionKind()
-	if gvk.Group == "" && gvk.Kind == "Service" {
-		clusterIP, found, err := unstructured.NestedString(current.Object, "spec", "clusterIP")
-		if err != nil {
-			return err
-		}
+	currentData, err := current.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize current object")
+	}
 
-		if found {
-			return unstructured.SetNestedField(updated.Object, clusterIP, "spec", "clusterIP")
-		}
+	patchMeta, err := patchMetaFor(updated.GroupVersionKind())
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
-}
+	patch, err := strategicpatch.CreateThreeWayMergePatch(original, modified, currentData, patchMeta, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute patch")
+	}
 
-// MergeServiceAccountForUpdate copies secrets from current to updated.
-// This is intended to preserve the auto-generated token.
-// Right now, we just copy current to updated and don't support supplying
-// any secrets ourselves.
-func MergeServiceAccountForUpdate(current, updated *unstructured.Unstructured) error {
-	gvk := updated.GroupVersionKind()
-	if gvk.Group == "" && gvk.Kind == "ServiceAccount" {
-		curSecrets, ok, err := unstructured.NestedSlice(current.Object, "secrets")
-		if err != nil {
-			return err
-		}
+	mergedData, err := strategicpatch.StrategicMergePatchUsingLookupPatchMeta(currentData, patch, patchMeta)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to apply patch")
+	}
 
-		if ok {
-			unstructured.SetNestedField(updated.Object, curSecrets, "secrets")
-		}
+	merged := &unstructured.Unstructured{}
+	if err := merged.UnmarshalJSON(mergedData); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize merged object")
 	}
-	return nil
-}
 
-// mergeAnnotations copies over any annotations from current to updated,
-// with updated winning if there's a conflict
-func mergeAnnotations(current, updated *unstructured.Unstructured) {
-	updatedAnnotations := updated.GetAnnotations()
-	curAnnotations := current.GetAnnotations()
+	return merged, nil
+}
 
-	if curAnnotations == nil {
-		curAnnotations = map[string]string{}
+// patchMetaFor looks up patch strategies of the given kind. Kinds unknown to
+// the client, such as CRDs, get a strategy replacing all lists.
+func patchMetaFor(gvk schema.GroupVersionKind) (strategicpatch.LookupPatchMeta, error) {
+	obj, err := scheme.Scheme.New(gvk)
+	if runtime.IsNotRegisteredError(err) {
+		return replacingPatchMeta{}, nil
 	}
-
-	for k, v := range updatedAnnotations {
-		curAnnotations[k] = v
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to look up kind %s", gvk.String())
 	}
 
-	updated.SetAnnotations(curAnnotations)
+	return strategicpatch.NewPatchMetaFromStruct(obj)
 }
 
-// mergeLabels copies over any labels from current to updated,
-// with updated winning if there's a conflict
-func mergeLabels(current, updated *unstructured.Unstructured) {
-	updatedLabels := updated.GetLabels()
-	curLabels := current.GetLabels()
+// replacingPatchMeta has no patch strategy for any field, so lists are
+// replaced as a whole, the same as in JSON merge patch
+type replacingPatchMeta struct{}
 
-	if curLabels == nil {
-		curLabels = map[string]string{}
-	}
+func (m replacingPatchMeta) LookupPatchMetadataForStruct(key string) (strategicpatch.LookupPatchMeta, strategicpatch.PatchMeta, error) {
+	return m, strategicpatch.PatchMeta{}, nil
+}
 
-	for k, v := range updatedLabels {
-		curLabels[k] = v
-	}
+func (m replacingPatchMeta) LookupPatchMetadataForSlice(key string) (strategicpatch.LookupPatchMeta, strategicpatch.PatchMeta, error) {
+	return m, strategicpatch.PatchMeta{}, nil
+}
 
-	updated.SetLabels(curLabels)
+func (m replacingPatchMeta) Name() string {
+	return "replacing"
 }
 
 // IsObjectSupported rejects objects with configurations we don't support.
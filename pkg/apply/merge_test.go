@@ -11,22 +11,57 @@ import (
 )
 
 var _ = Describe("MergeObjectForUpdate", func() {
-	// Namespaces use the "generic" logic; deployments and services
-	// have custom logic
+	// merge applies the last applied object on the server, modifies it there as other
+	// controllers would and merges the updated object into the result
+	merge := func(lastApplied, onServer, updated string) *unstructured.Unstructured {
+		last := k8s.UnstructuredFromYaml(lastApplied)
+		Expect(apply.SetLastApplied(last)).To(Succeed())
+
+		cur := k8s.UnstructuredFromYaml(onServer)
+		annotations := cur.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[apply.LastAppliedAnnotation] = last.GetAnnotations()[apply.LastAppliedAnnotation]
+		cur.SetAnnotations(annotations)
+
+		upd := k8s.UnstructuredFromYaml(updated)
+		Expect(apply.SetLastApplied(upd)).To(Succeed())
+
+		merged, err := apply.MergeObjectForUpdate(cur, upd)
+		Expect(err).NotTo(HaveOccurred())
+		return merged
+	}
+
 	Context("when given a generic object (Namespace)", func() {
-		cur := k8s.UnstructuredFromYaml(`
+		var merged *unstructured.Unstructured
+
+		BeforeEach(func() {
+			merged = merge(`
 apiVersion: v1
 kind: Namespace
 metadata:
   name: ns1
   labels:
-    a: cur
-    b: cur
+    a: last
+    b: last
   annotations:
-    a: cur
-    b: cur`)
-
-		upd := k8s.UnstructuredFromYaml(`
+    a: last
+    b: last`, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: ns1
+  resourceVersion: "439"
+  uid: e0ecf168-8d18-11e9-b398-525500d15501
+  labels:
+    a: last
+    b: last
+    other: cur
+  annotations:
+    a: last
+    b: last
+    other: cur`, `
 apiVersion: v1
 kind: Namespace
 metadata:
@@ -37,242 +72,253 @@ metadata:
   annotations:
     a: upd
     c: upd`)
-
-		It("should successfully merge", func() {
-			// this mutates updated
-			err := apply.MergeObjectForUpdate(cur, upd)
-			Expect(err).NotTo(HaveOccurred())
 		})
 
-		It("should merge annotations", func() {
-			Expect(upd.GetLabels()).To(Equal(map[string]string{
-				"a": "upd",
-				"b": "cur",
-				"c": "upd",
+		It("should update rendered labels, remove dropped ones and keep those set by others", func() {
+			Expect(merged.GetLabels()).To(Equal(map[string]string{
+				"a":     "upd",
+				"c":     "upd",
+				"other": "cur",
 			}))
 		})
 
-		It("should overwrite everything else", func() {
-			Expect(upd.GetAnnotations()).To(Equal(map[string]string{
-				"a": "upd",
-				"b": "cur",
-				"c": "upd",
+		It("should do the same with annotations and record the updated object", func() {
+			annotations := merged.GetAnnotations()
+			Expect(annotations).To(HaveKey(apply.LastAppliedAnnotation))
+			delete(annotations, apply.LastAppliedAnnotation)
+			Expect(annotations).To(Equal(map[string]string{
+				"a":     "upd",
+				"c":     "upd",
+				"other": "cur",
 			}))
 		})
+
+		It("should keep metadata populated by the apiserver", func() {
+			Expect(merged.GetResourceVersion()).To(Equal("439"))
+			Expect(string(merged.GetUID())).To(Equal("e0ecf168-8d18-11e9-b398-525500d15501"))
+		})
 	})
 
-	Context("when given a Deployment", func() {
-		cur := k8s.UnstructuredFromYaml(`
+	Context("when given a Deployment modified by other controllers", func() {
+		var merged *unstructured.Unstructured
+
+		BeforeEach(func() {
+			merged = merge(`
 apiVersion: apps/v1
 kind: Deployment
 metadata:
   name: d1
-  labels:
-    a: cur
-    b: cur
-  annotations:
-    deployment.kubernetes.io/revision: cur
-    a: cur
-    b: cur`)
-
-		upd := k8s.UnstructuredFromYaml(`
+spec:
+  template:
+    spec:
+      containers:
+      - name: main
+        image: main:old
+        args:
+        - --dropped`, `
 apiVersion: apps/v1
 kind: Deployment
 metadata:
   name: d1
-  labels:
-    a: upd
-    c: upd
   annotations:
-    deployment.kubernetes.io/revision: upd
-    a: upd
-    c: upd`)
-
-		It("should successfully merge", func() {
-			// this mutates updated
-			err := apply.MergeObjectForUpdate(cur, upd)
-			Expect(err).NotTo(HaveOccurred())
+    deployment.kubernetes.io/revision: "3"
+spec:
+  replicas: 5
+  template:
+    spec:
+      containers:
+      - name: main
+        image: main:old
+        args:
+        - --dropped
+      - name: sidecar
+        image: sidecar`, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: d1
+spec:
+  template:
+    spec:
+      containers:
+      - name: main
+        image: main:new`)
 		})
 
-		It("should merge annotations", func() {
-			Expect(upd.GetAnnotations()).To(Equal(map[string]string{
-				"a": "upd",
-				"b": "cur",
-				"c": "upd",
+		It("should keep the revision annotation", func() {
+			Expect(merged.GetAnnotations()).To(HaveKeyWithValue("deployment.kubernetes.io/revision", "3"))
+		})
 
-				"deployment.kubernetes.io/revision": "cur",
-			}))
+		It("should keep replicas set by an autoscaler", func() {
+			replicas, found, err := unstructured.NestedInt64(merged.Object, "spec", "replicas")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(replicas).To(Equal(int64(5)))
 		})
 
-		It("should not merge labels", func() {
-			Expect(upd.GetLabels()).To(Equal(map[string]string{
-				"a": "upd",
-				"b": "cur",
-				"c": "upd",
+		It("should update the rendered container and keep the injected sidecar", func() {
+			containers, _, err := unstructured.NestedSlice(merged.Object, "spec", "template", "spec", "containers")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(containers).To(Equal([]interface{}{
+				map[string]interface{}{"name": "main", "image": "main:new"},
+				map[string]interface{}{"name": "sidecar", "image": "sidecar"},
 			}))
 		})
 	})
 
 	Context("when given a Service", func() {
-		cur := k8s.UnstructuredFromYaml(`
+		It("should keep the original clusterIP", func() {
+			merged := merge(`
 apiVersion: v1
 kind: Service
 metadata:
-  name: d1
-spec:
-  clusterIP: cur`)
-
-		upd := k8s.UnstructuredFromYaml(`
+  name: d1`, `
 apiVersion: v1
 kind: Service
 metadata:
   name: d1
 spec:
-  clusterIP: upd`)
-
-		It("should successfully merge", func() {
-			// this mutates updated
-			err := apply.MergeObjectForUpdate(cur, upd)
-			Expect(err).NotTo(HaveOccurred())
-		})
-
-		It("should keep the original clusterIP", func() {
-			ip, _, err := unstructured.NestedString(upd.Object, "spec", "clusterIP")
+  clusterIP: cur`, `
+apiVersion: v1
+kind: Service
+metadata:
+  name: d1`)
+			ip, _, err := unstructured.NestedString(merged.Object, "spec", "clusterIP")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(ip).To(Equal("cur"))
 		})
 	})
 
 	Context("when given a ServiceAccount", func() {
-		cur := k8s.UnstructuredFromYaml(`
+		It("should keep original secrets after merging", func() {
+			merged := merge(`
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: d1`, `
 apiVersion: v1
 kind: ServiceAccount
 metadata:
   name: d1
-  annotations:
-    a: cur
 secrets:
-- foo`)
-
-		upd := k8s.UnstructuredFromYaml(`
+- name: foo`, `
 apiVersion: v1
 kind: ServiceAccount
 metadata:
   name: d1
   annotations:
     b: upd`)
-
-		It("should successfully merge", func() {
-			// this mutates updated
-			err := apply.MergeObjectForUpdate(cur, upd)
-			Expect(err).NotTo(HaveOccurred())
-		})
-
-		It("should keep original secrets after merging", func() {
-			s, ok, err := unstructured.NestedSlice(upd.Object, "secrets")
+			s, ok, err := unstructured.NestedSlice(merged.Object, "secrets")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(ok).To(BeTrue())
-			Expect(s).To(ConsistOf("foo"))
+			Expect(s).To(ConsistOf(map[string]interface{}{"name": "foo"}))
 		})
 	})
 
-	Context("when merging an empty Deployment into an empty Deployment", func() {
-		cur := k8s.UnstructuredFromYaml(`
-apiVersion: apps/v1
-kind: Deployment
+	Context("when given a MutatingWebhookConfiguration with a caBundle patched on runtime", func() {
+		It("should keep the caBundle", func() {
+			merged := merge(`
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
 metadata:
-  name: d1`)
-
-		upd := k8s.UnstructuredFromYaml(`
-apiVersion: apps/v1
-kind: Deployment
+  name: w1
+webhooks:
+- name: a.example.com
+  clientConfig:
+    service:
+      name: svc
+      namespace: ns`, `
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
 metadata:
-  name: d1`)
-
-		It("should successfully merge", func() {
-			// this mutates updated
-			err := apply.MergeObjectForUpdate(cur, upd)
+  name: w1
+webhooks:
+- name: a.example.com
+  clientConfig:
+    caBundle: Y2E=
+    service:
+      name: svc
+      namespace: ns`, `
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+metadata:
+  name: w1
+webhooks:
+- name: a.example.com
+  failurePolicy: Fail
+  clientConfig:
+    service:
+      name: svc
+      namespace: ns`)
+			webhooks, _, err := unstructured.NestedSlice(merged.Object, "webhooks")
 			Expect(err).NotTo(HaveOccurred())
-		})
-
-		It("should stay empty", func() {
-			Expect(upd.GetLabels()).To(BeEmpty())
+			Expect(webhooks).To(HaveLen(1))
+			webhook := webhooks[0].(map[string]interface{})
+			Expect(webhook).To(HaveKeyWithValue("failurePolicy", "Fail"))
+			Expect(webhook["clientConfig"]).To(HaveKeyWithValue("caBundle", "Y2E="))
 		})
 	})
 
-	Context("when merging a non-empty Deployment into an empty Deployment", func() {
-		cur := k8s.UnstructuredFromYaml(`
-apiVersion: apps/v1
-kind: Deployment
+	Context("when given a custom resource", func() {
+		It("should replace lists as a whole", func() {
+			merged := merge(`
+apiVersion: example.com/v1
+kind: Foo
 metadata:
-  name: d1`)
-
-		upd := k8s.UnstructuredFromYaml(`
-apiVersion: apps/v1
-kind: Deployment
+  name: f1
+spec:
+  items:
+  - a
+  - b`, `
+apiVersion: example.com/v1
+kind: Foo
 metadata:
-  name: d1
-  labels:
-    a: upd
-    c: upd
-  annotations:
-    a: upd
-    c: upd`)
-
-		It("should successfully merge", func() {
-			// this mutates updated
-			err := apply.MergeObjectForUpdate(cur, upd)
+  name: f1
+spec:
+  items:
+  - a
+  - b
+  other: cur`, `
+apiVersion: example.com/v1
+kind: Foo
+metadata:
+  name: f1
+spec:
+  items:
+  - c`)
+			spec, _, err := unstructured.NestedMap(merged.Object, "spec")
 			Expect(err).NotTo(HaveOccurred())
-		})
-
-		It("should use values from the updating Deployment", func() {
-			Expect(upd.GetLabels()).To(Equal(map[string]string{
-				"a": "upd",
-				"c": "upd",
-			}))
-
-			Expect(upd.GetAnnotations()).To(Equal(map[string]string{
-				"a": "upd",
-				"c": "upd",
+			Expect(spec).To(Equal(map[string]interface{}{
+				"items": []interface{}{"c"},
+				"other": "cur",
 			}))
 		})
 	})
 
-	Context("when merging an empty Deployment into a non-empty Deployment", func() {
-		cur := k8s.UnstructuredFromYaml(`
-apiVersion: apps/v1
-kind: Deployment
+	Context("when the current object was not applied with the last applied configuration", func() {
+		It("should merge the updated object into it", func() {
+			cur := k8s.UnstructuredFromYaml(`
+apiVersion: v1
+kind: ConfigMap
 metadata:
-  name: d1
-  labels:
-    a: cur
-    b: cur
-  annotations:
-    a: cur
-    b: cur`)
-
-		upd := k8s.UnstructuredFromYaml(`
-apiVersion: apps/v1
-kind: Deployment
+  name: cm1
+data:
+  a: cur
+  b: cur`)
+			upd := k8s.UnstructuredFromYaml(`
+apiVersion: v1
+kind: ConfigMap
 metadata:
-  name: d1`)
+  name: cm1
+data:
+  a: upd`)
+			Expect(apply.SetLastApplied(upd)).To(Succeed())
 
-		It("should successfully merge", func() {
-			// this mutates updated
-			err := apply.MergeObjectForUpdate(cur, upd)
+			merged, err := apply.MergeObjectForUpdate(cur, upd)
 			Expect(err).NotTo(HaveOccurred())
-		})
-
-		It("keep the original values and not overwrite them with pure void and emptiness", func() {
-			Expect(upd.GetLabels()).To(Equal(map[string]string{
-				"a": "cur",
-				"b": "cur",
-			}))
-
-			Expect(upd.GetAnnotations()).To(Equal(map[string]string{
-				"a": "cur",
-				"b": "cur",
-			}))
+			data, _, err := unstructured.NestedStringMap(merged.Object, "data")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal(map[string]string{"a": "upd", "b": "cur"}))
 		})
 	})
 })
@@ -295,34 +341,3 @@ secrets:
 		})
 	})
 })
-
-var _ = Describe("MergeMetadataForUpdate", func() {
-	Context("when given current unstructured and empty updated", func() {
-		current := k8s.UnstructuredFromYaml(`
-apiVersion: v1
-kind: Deployment
-metadata:
-  name: foo
-  creationTimestamp: 2019-06-12T13:49:20Z
-  generation: 1
-  resourceVersion: "439"
-  selfLink: /apis/extensions/v1beta1/namespaces/kube-system/deployments/foo
-  uid: e0ecf168-8d18-11e9-b398-525500d15501
-`)
-		updated := k8s.UnstructuredFromYaml(`
-apiVersion: v1
-kind: Deployment
-metadata:
-  name: foo`)
-
-		It("should merge metadate from current to updated", func() {
-			err := apply.MergeMetadataForUpdate(current, updated)
-			Expect(err).ToNot(HaveOccurred())
-			Expect(updated.GetCreationTimestamp()).To(Equal(current.GetCreationTimestamp()))
-			Expect(updated.GetGeneration()).To(Equal(current.GetGeneration()))
-			Expect(updated.GetResourceVersion()).To(Equal(current.GetResourceVersion()))
-			Expect(updated.GetSelfLink()).To(Equal(current.GetSelfLink()))
-			Expect(updated.GetUID()).To(Equal(current.GetUID()))
-		})
-	})
-})
@@ -0,0 +1,54 @@
+package apply
+
+import (
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+// PatchObject applies a JSON or strategic merge patch on the object. Strategic
+// merge patches of kinds unknown to the client replace lists as a whole.
+func PatchObject(obj *unstructured.Unstructured, patchType types.PatchType, patch []byte) (*unstructured.Unstructured, error) {
+	original, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize object")
+	}
+
+	var patched []byte
+	switch patchType {
+	case types.JSONPatchType:
+		decoded, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode JSON patch")
+		}
+		patched, err = decoded.Apply(original)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to apply JSON patch")
+		}
+	case types.StrategicMergePatchType:
+		patchMeta, err := patchMetaFor(obj.GroupVersionKind())
+		if err != nil {
+			return nil, err
+		}
+		patched, err = strategicpatch.StrategicMergePatchUsingLookupPatchMeta(original, patch, patchMeta)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to apply strategic merge patch")
+		}
+	default:
+		return nil, errors.Errorf("unsupported patch type %s", patchType)
+	}
+
+	result := &unstructured.Unstructured{}
+	if err := result.UnmarshalJSON(patched); err != nil {
+		return nil, errors.Wrap(err, "failed to deserialize patched object")
+	}
+
+	if result.GroupVersionKind() != obj.GroupVersionKind() || result.GetNamespace() != obj.GetNamespace() || result.GetName() != obj.GetName() {
+		return nil, errors.Errorf("patch must not change identity of the object")
+	}
+
+	return result, nil
+}
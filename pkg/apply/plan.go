@@ -0,0 +1,149 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Actions which may be planned for an object
+const (
+	ActionCreate   = "Create"
+	ActionUpdate   = "Update"
+	ActionRecreate = "Recreate"
+	ActionDelete   = "Delete"
+)
+
+// PlannedChange describes what would happen with an object if it was applied or deleted
+type PlannedChange struct {
+	Action     string `json:"action"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// Fields lists paths of fields which would be changed by an update or recreation
+	Fields []string `json:"fields,omitempty"`
+}
+
+// PlanObject finds out what ApplyObject would do with the desired object, without changing
+// anything. Changes of immutable fields of kinds which ApplyObject recreates are planned as
+// recreation, see recreatePolicies. It returns nil if the object is up to date.
+func PlanObject(ctx context.Context, client k8sclient.Client, obj *uns.Unstructured) (*PlannedChange, error) {
+	obj = obj.DeepCopy()
+	objDesc := fmt.Sprintf("(%s) %s/%s", obj.GroupVersionKind().String(), obj.GetNamespace(), obj.GetName())
+
+	if err := IsObjectSupported(obj); err != nil {
+		return nil, errors.Wrapf(err, "object %s unsupported", objDesc)
+	}
+	if err := SetLastApplied(obj); err != nil {
+		return nil, errors.Wrapf(err, "could not record last applied configuration of %s", objDesc)
+	}
+
+	existing := &uns.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	err := client.Get(ctx, types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, existing)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return plannedChange(ActionCreate, obj, nil), nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not retrieve existing %s", objDesc)
+	}
+
+	merged, err := MergeObjectForUpdate(existing, obj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not merge object %s with existing", objDesc)
+	}
+	if equality.Semantic.DeepEqual(existing, merged) {
+		return nil, nil
+	}
+
+	fields := changedFields(existing.Object, merged.Object, "")
+	if requiresRecreate(obj.GroupVersionKind().GroupKind(), fields) {
+		return plannedChange(ActionRecreate, obj, fields), nil
+	}
+	return plannedChange(ActionUpdate, obj, fields), nil
+}
+
+// PlanRemoval finds out whether the object exists and would be deleted. It returns nil if the
+// object is gone already.
+func PlanRemoval(ctx context.Context, client k8sclient.Client, obj *uns.Unstructured) (*PlannedChange, error) {
+	existing := &uns.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	err := client.Get(ctx, types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, existing)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not retrieve existing (%s) %s/%s", obj.GroupVersionKind().String(), obj.GetNamespace(), obj.GetName())
+	}
+
+	return plannedChange(ActionDelete, obj, nil), nil
+}
+
+func plannedChange(action string, obj *uns.Unstructured, fields []string) *PlannedChange {
+	return &PlannedChange{
+		Action:     action,
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Fields:     fields,
+	}
+}
+
+// changedFields lists paths of fields which differ between the two objects. Nested maps are
+// compared field by field, anything else, including lists, is compared as a whole. The record
+// of the last applied configuration is left out, it changes together with the rest.
+func changedFields(current, updated map[string]interface{}, prefix string) []string {
+	keys := map[string]bool{}
+	for key := range current {
+		keys[key] = true
+	}
+	for key := range updated {
+		keys[key] = true
+	}
+
+	fields := []string{}
+	for key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if path == "metadata.annotations."+LastAppliedAnnotation {
+			continue
+		}
+
+		currentMap, currentIsMap := current[key].(map[string]interface{})
+		updatedMap, updatedIsMap := updated[key].(map[string]interface{})
+		if currentIsMap && updatedIsMap {
+			fields = append(fields, changedFields(currentMap, updatedMap, path)...)
+		} else if !equality.Semantic.DeepEqual(current[key], updated[key]) {
+			fields = append(fields, path)
+		}
+	}
+
+	sort.Strings(fields)
+	return fields
+}
+
+// String summarizes the change in a single line
+func (c PlannedChange) String() string {
+	name := c.Name
+	if c.Namespace != "" {
+		name = c.Namespace + "/" + c.Name
+	}
+	summary := fmt.Sprintf("%s (%s, Kind=%s) %s", c.Action, c.APIVersion, c.Kind, name)
+	if len(c.Fields) > 0 {
+		summary += ": " + strings.Join(c.Fields, ", ")
+	}
+	return summary
+}
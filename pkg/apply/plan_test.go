@@ -0,0 +1,120 @@
+package apply_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/util/k8s"
+)
+
+var _ = Describe("PlanObject", func() {
+	var client k8sclient.Client
+
+	applied := k8s.UnstructuredFromYaml(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: d1
+  namespace: ns
+  labels:
+    app: d1
+spec:
+  replicas: 1`)
+
+	BeforeEach(func() {
+		client = fake.NewFakeClient()
+		_, err := apply.ApplyObject(context.Background(), client, nil, applied.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Context("when the object does not exist", func() {
+		It("should plan its creation", func() {
+			object := applied.DeepCopy()
+			object.SetName("d2")
+
+			change, err := apply.PlanObject(context.Background(), client, object)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*change).To(Equal(apply.PlannedChange{Action: apply.ActionCreate, APIVersion: "apps/v1", Kind: "Deployment", Namespace: "ns", Name: "d2"}))
+		})
+	})
+
+	Context("when the object is up to date", func() {
+		It("should plan nothing", func() {
+			change, err := apply.PlanObject(context.Background(), client, applied.DeepCopy())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(change).To(BeNil())
+		})
+	})
+
+	Context("when the object was changed", func() {
+		It("should plan its update listing changed fields and leave it untouched", func() {
+			object := k8s.UnstructuredFromYaml(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: d1
+  namespace: ns
+  labels:
+    tier: backend
+spec:
+  replicas: 2`)
+
+			change, err := apply.PlanObject(context.Background(), client, object)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(change.Action).To(Equal(apply.ActionUpdate))
+			Expect(change.Fields).To(Equal([]string{"metadata.labels.app", "metadata.labels.tier", "spec.replicas"}))
+			Expect(change.String()).To(Equal("Update (apps/v1, Kind=Deployment) ns/d1: metadata.labels.app, metadata.labels.tier, spec.replicas"))
+
+			change, err = apply.PlanObject(context.Background(), client, applied.DeepCopy())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(change).To(BeNil())
+		})
+	})
+
+	Context("when an immutable field of the object was changed", func() {
+		It("should plan its recreation", func() {
+			object := applied.DeepCopy()
+			Expect(unstructured.SetNestedStringMap(object.Object, map[string]string{"app": "d1"}, "spec", "selector", "matchLabels")).To(Succeed())
+
+			change, err := apply.PlanObject(context.Background(), client, object)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(change.Action).To(Equal(apply.ActionRecreate))
+			Expect(change.Fields).To(Equal([]string{"spec.selector"}))
+		})
+	})
+})
+
+var _ = Describe("PlanRemoval", func() {
+	var client k8sclient.Client
+
+	object := k8s.UnstructuredFromYaml(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+  namespace: ns`)
+
+	BeforeEach(func() {
+		client = fake.NewFakeClient()
+	})
+
+	It("should plan deletion of an existing object only", func() {
+		change, err := apply.PlanRemoval(context.Background(), client, object)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(change).To(BeNil())
+
+		err = client.Create(context.Background(), object.DeepCopy())
+		Expect(err).ToNot(HaveOccurred())
+
+		change, err = apply.PlanRemoval(context.Background(), client, object)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(change.Action).To(Equal(apply.ActionDelete))
+	})
+})
@@ -0,0 +1,78 @@
+package apply
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// readinessChecks lists kinds which are not usable right after they are created, together with
+// a check telling whether the object, as read from the apiserver, is ready. Objects of other kinds
+// are considered ready once they are applied.
+var readinessChecks = map[schema.GroupKind]func(obj *uns.Unstructured) bool{
+	// Custom resources are rejected until their CRD is established
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}: func(obj *uns.Unstructured) bool {
+		return hasTrueCondition(obj, "Established")
+	},
+	// Objects cannot be created in a namespace which is not active yet
+	{Group: "", Kind: "Namespace"}: func(obj *uns.Unstructured) bool {
+		phase, _, _ := uns.NestedString(obj.Object, "status", "phase")
+		return phase == "Active"
+	},
+}
+
+// IsReady checks whether the object read from the apiserver can be used by objects which depend
+// on it, e.g. whether a CRD is established
+func IsReady(obj *uns.Unstructured) bool {
+	isReady, found := readinessChecks[obj.GroupVersionKind().GroupKind()]
+	if !found {
+		return true
+	}
+	return isReady(obj)
+}
+
+// NotReady returns the first of the given objects which is not ready on the apiserver yet, see
+// IsReady. Objects which were not created yet are not ready either. Nil is returned when all the
+// objects are ready. It does not wait, so the caller can retry later without blocking.
+func NotReady(ctx context.Context, client k8sclient.Client, objs []*uns.Unstructured) (*uns.Unstructured, error) {
+	for _, obj := range objs {
+		gvk := obj.GroupVersionKind()
+		if _, found := readinessChecks[gvk.GroupKind()]; !found {
+			continue
+		}
+
+		current := &uns.Unstructured{}
+		current.SetGroupVersionKind(gvk)
+		err := client.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, current)
+		if apierrors.IsNotFound(err) {
+			return obj, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to check readiness of (%s) %s/%s", gvk.String(), obj.GetNamespace(), obj.GetName())
+		}
+		if !IsReady(current) {
+			return obj, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func hasTrueCondition(obj *uns.Unstructured, conditionType string) bool {
+	conditions, _, _ := uns.NestedSlice(obj.Object, "status", "conditions")
+	for _, condition := range conditions {
+		condition, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == conditionType && condition["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
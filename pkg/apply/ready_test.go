@@ -0,0 +1,120 @@
+package apply_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/util/k8s"
+)
+
+var _ = Describe("IsReady", func() {
+	DescribeTable("should check readiness of the object by its kind", func(manifest string, ready bool) {
+		Expect(apply.IsReady(k8s.UnstructuredFromYaml(manifest))).To(Equal(ready))
+	},
+		Entry("established CRD", `
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: foos.example.com
+status:
+  conditions:
+  - type: NamesAccepted
+    status: "True"
+  - type: Established
+    status: "True"`, true),
+		Entry("CRD which is not established yet", `
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: foos.example.com
+status:
+  conditions:
+  - type: NamesAccepted
+    status: "True"
+  - type: Established
+    status: "False"`, false),
+		Entry("CRD without status", `
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: foos.example.com`, false),
+		Entry("active namespace", `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: ns
+status:
+  phase: Active`, true),
+		Entry("terminating namespace", `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: ns
+status:
+  phase: Terminating`, false),
+		Entry("kind without readiness check", `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: ns`, true),
+	)
+})
+
+var _ = Describe("NotReady", func() {
+	namespace := func(phase string) *unstructured.Unstructured {
+		return k8s.UnstructuredFromYaml(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: ns
+status:
+  phase: ` + phase)
+	}
+
+	// ConfigMap has no readiness check, so it is not even looked up
+	configMap := k8s.UnstructuredFromYaml(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: ns`)
+
+	Context("when all objects are ready", func() {
+		It("should return nothing", func() {
+			client := fake.NewFakeClient(namespace("Active"))
+
+			notReady, err := apply.NotReady(context.TODO(), client, []*unstructured.Unstructured{namespace("Active"), configMap})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(notReady).To(BeNil())
+		})
+	})
+
+	Context("when an object is not ready yet", func() {
+		It("should return it without waiting", func() {
+			client := fake.NewFakeClient(namespace("Terminating"))
+
+			notReady, err := apply.NotReady(context.TODO(), client, []*unstructured.Unstructured{configMap, namespace("Active")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(notReady).NotTo(BeNil())
+			Expect(notReady.GetName()).To(Equal("ns"))
+		})
+	})
+
+	Context("when an object was not created yet", func() {
+		It("should return it", func() {
+			client := fake.NewFakeClient()
+
+			notReady, err := apply.NotReady(context.TODO(), client, []*unstructured.Unstructured{namespace("Active")})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(notReady).NotTo(BeNil())
+		})
+	})
+})
@@ -0,0 +1,100 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// recreatePolicy describes how an object of a kind is recreated
+type recreatePolicy struct {
+	// propagation is used to delete the object
+	propagation metav1.DeletionPropagation
+	// immutableFields are paths of fields which cannot be updated, changing any of them, or
+	// fields nested in them, makes the object recreated
+	immutableFields []string
+}
+
+// recreatePolicies lists kinds which are deleted and created again when a change of an immutable
+// field is rejected by the apiserver, together with the propagation policy used to delete them.
+// Workloads are removed in foreground, so their pods are gone before new ones are started. Pods
+// orphaned in background would not match a changed selector and would stay around forever.
+var recreatePolicies = map[schema.GroupKind]recreatePolicy{
+	{Group: "apps", Kind: "DaemonSet"}:  {metav1.DeletePropagationForeground, []string{"spec.selector"}},
+	{Group: "apps", Kind: "Deployment"}: {metav1.DeletePropagationForeground, []string{"spec.selector"}},
+	{Group: "", Kind: "Service"}:        {metav1.DeletePropagationBackground, []string{"spec.clusterIP"}},
+}
+
+// IsImmutableFieldError checks whether the update was rejected because it changed a field which
+// cannot be updated, such as selector of a DaemonSet
+func IsImmutableFieldError(err error) bool {
+	return apierrors.IsInvalid(err) && strings.Contains(err.Error(), "field is immutable")
+}
+
+// requiresRecreate checks whether any of the changed fields of an object of the given kind is
+// immutable, so the object would be recreated by ApplyObject instead of updated
+func requiresRecreate(groupKind schema.GroupKind, changedFields []string) bool {
+	policy, ok := recreatePolicies[groupKind]
+	if !ok {
+		return false
+	}
+	for _, field := range changedFields {
+		for _, immutable := range policy.immutableFields {
+			if field == immutable || strings.HasPrefix(field, immutable+".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// recreatePendingError is returned by ApplyObject when the object is being deleted to be
+// recreated. The deletion is not waited for, the object is created by a later call of ApplyObject
+// once it is gone.
+type recreatePendingError struct {
+	objDesc string
+}
+
+func (e *recreatePendingError) Error() string {
+	return fmt.Sprintf("%s is being deleted, it will be created again once it is gone", e.objDesc)
+}
+
+// IsRecreatePending checks whether ApplyObject failed only because the object is still being
+// deleted, so it should be applied again later
+func IsRecreatePending(err error) bool {
+	_, ok := errors.Cause(err).(*recreatePendingError)
+	return ok
+}
+
+// recreateObject deletes the existing object, so the desired one can be created instead once it
+// is gone. It is used only for kinds listed in recreatePolicies.
+func recreateObject(ctx context.Context, client k8sclient.Client, recorder record.EventRecorder, existing, obj *uns.Unstructured, reason error) error {
+	gvk := obj.GroupVersionKind()
+	objDesc := fmt.Sprintf("(%s) %s/%s", gvk.String(), obj.GetNamespace(), obj.GetName())
+
+	policy, ok := recreatePolicies[gvk.GroupKind()]
+	if !ok {
+		return reason
+	}
+
+	log.Printf("immutable field of %s was changed, deleting it to be recreated: %v", objDesc, reason)
+	err := client.Delete(ctx, existing, k8sclient.PropagationPolicy(policy.propagation))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "could not delete %s to be recreated", objDesc)
+	}
+	if recorder != nil {
+		recorder.Eventf(existing, corev1.EventTypeNormal, "Recreating", "%s %s is being recreated, since an immutable field was changed", gvk.Kind, obj.GetName())
+	}
+
+	return &recreatePendingError{objDesc: objDesc}
+}
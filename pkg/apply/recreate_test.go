@@ -0,0 +1,172 @@
+package apply_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/util/k8s"
+)
+
+// immutableSelectorClient rejects any update of an object, as if its selector was changed
+type immutableSelectorClient struct {
+	k8sclient.Client
+}
+
+func (c immutableSelectorClient) Update(ctx context.Context, obj runtime.Object) error {
+	accessor := obj.(interface{ GetName() string })
+	gk := obj.GetObjectKind().GroupVersionKind().GroupKind()
+	return apierrors.NewInvalid(gk, accessor.GetName(), field.ErrorList{
+		field.Invalid(field.NewPath("spec", "selector"), "", "field is immutable"),
+	})
+}
+
+var _ = Describe("ApplyObject with a changed immutable field", func() {
+	var client k8sclient.Client
+	var recorder *record.FakeRecorder
+
+	BeforeEach(func() {
+		original := k8s.UnstructuredFromYaml(`
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: ds1
+  namespace: ns
+  uid: e0ecf168-8d18-11e9-b398-525500d15501
+spec:
+  selector:
+    matchLabels:
+      app: old`)
+		client = immutableSelectorClient{fake.NewFakeClient(original)}
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	Context("and the object is of a known kind", func() {
+		object := k8s.UnstructuredFromYaml(`
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: ds1
+  namespace: ns
+spec:
+  selector:
+    matchLabels:
+      app: new`)
+
+		It("should delete it without waiting and record an event", func() {
+			changed, err := apply.ApplyObject(context.Background(), client, recorder, object.DeepCopy())
+			Expect(apply.IsRecreatePending(err)).To(BeTrue())
+			Expect(changed).To(BeTrue())
+
+			err = client.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "ds1"}, &appsv1.DaemonSet{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			Expect(recorder.Events).To(Receive(ContainSubstring("Recreating")))
+		})
+
+		It("should create it once it is gone", func() {
+			_, err := apply.ApplyObject(context.Background(), client, recorder, object.DeepCopy())
+			Expect(apply.IsRecreatePending(err)).To(BeTrue())
+
+			_, err = apply.ApplyObject(context.Background(), client, recorder, object.DeepCopy())
+			Expect(err).ToNot(HaveOccurred())
+
+			found := &appsv1.DaemonSet{}
+			err = client.Get(context.Background(), types.NamespacedName{Namespace: "ns", Name: "ds1"}, found)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found.Spec.Selector.MatchLabels).To(Equal(map[string]string{"app": "new"}))
+			Expect(string(found.GetUID())).NotTo(Equal("e0ecf168-8d18-11e9-b398-525500d15501"))
+		})
+	})
+
+	Context("and the object is still being deleted", func() {
+		BeforeEach(func() {
+			deleted := k8s.UnstructuredFromYaml(`
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: ds2
+  namespace: ns
+  deletionTimestamp: "2019-06-12T10:00:00Z"
+spec:
+  selector:
+    matchLabels:
+      app: old`)
+			client = immutableSelectorClient{fake.NewFakeClient(deleted)}
+		})
+
+		object := k8s.UnstructuredFromYaml(`
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: ds2
+  namespace: ns
+spec:
+  selector:
+    matchLabels:
+      app: new`)
+
+		It("should not touch it and report that recreation is pending", func() {
+			changed, err := apply.ApplyObject(context.Background(), client, recorder, object.DeepCopy())
+			Expect(apply.IsRecreatePending(err)).To(BeTrue())
+			Expect(changed).To(BeFalse())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+
+	Context("and the object is of an unknown kind", func() {
+		BeforeEach(func() {
+			err := client.Create(context.Background(), k8s.UnstructuredFromYaml(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+  namespace: ns
+data:
+  foo: old`))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		object := k8s.UnstructuredFromYaml(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm1
+  namespace: ns
+data:
+  foo: new`)
+
+		It("should fail", func() {
+			_, err := apply.ApplyObject(context.Background(), client, recorder, object)
+			Expect(err).To(HaveOccurred())
+			Expect(apply.IsImmutableFieldError(errors.Cause(err))).To(BeTrue())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("IsImmutableFieldError", func() {
+	It("should not match other invalid errors", func() {
+		err := apierrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "DaemonSet"}, "ds1", field.ErrorList{
+			field.Required(field.NewPath("spec", "template"), ""),
+		})
+		Expect(apply.IsImmutableFieldError(err)).To(BeFalse())
+	})
+
+	It("should not match nil", func() {
+		Expect(apply.IsImmutableFieldError(nil)).To(BeFalse())
+	})
+})
@@ -2,15 +2,17 @@ package components
 
 import (
 	"fmt"
+	"strings"
 
-	cnav1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	opv1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/names"
 )
 
 const (
@@ -28,6 +30,9 @@ const (
 	OvsMarkerImageDefault         = "quay.io/kubevirt/ovs-cni-marker:v0.9.0"
 )
 
+// ArchitecturesDefault lists architectures supported by the default images
+var ArchitecturesDefault = []string{"amd64"}
+
 type AddonsImages struct {
 	Multus            string
 	LinuxBridgeCni    string
@@ -36,6 +41,16 @@ type AddonsImages struct {
 	NMStateHandler    string
 	OvsCni            string
 	OvsMarker         string
+
+	// Architectures supported by respective images, images supporting more than one architecture
+	// are expected to be manifest lists
+	MultusArchitectures            []string
+	LinuxBridgeCniArchitectures    []string
+	LinuxBridgeMarkerArchitectures []string
+	KubeMacPoolArchitectures       []string
+	NMStateHandlerArchitectures    []string
+	OvsCniArchitectures            []string
+	OvsMarkerArchitectures         []string
 }
 
 func (ai *AddonsImages) FillDefaults() *AddonsImages {
@@ -60,6 +75,19 @@ func (ai *AddonsImages) FillDefaults() *AddonsImages {
 	if ai.OvsMarker == "" {
 		ai.OvsMarker = OvsMarkerImageDefault
 	}
+	for _, architectures := range []*[]string{
+		&ai.MultusArchitectures,
+		&ai.LinuxBridgeCniArchitectures,
+		&ai.LinuxBridgeMarkerArchitectures,
+		&ai.KubeMacPoolArchitectures,
+		&ai.NMStateHandlerArchitectures,
+		&ai.OvsCniArchitectures,
+		&ai.OvsMarkerArchitectures,
+	} {
+		if len(*architectures) == 0 {
+			*architectures = append([]string{}, ArchitecturesDefault...)
+		}
+	}
 	return ai
 }
 
@@ -74,7 +102,7 @@ func GetDeployment(version string, operatorVersion string, namespace string, rep
 			Name:      Name,
 			Namespace: namespace,
 			Annotations: map[string]string{
-				opv1alpha1.SchemeGroupVersion.Group + "/version": operatorVersion,
+				opv1.SchemeGroupVersion.Group + "/version": operatorVersion,
 			},
 		},
 		Spec: appsv1.DeploymentSpec{
@@ -100,35 +128,70 @@ func GetDeployment(version string, operatorVersion string, namespace string, rep
 							Name:            Name,
 							Image:           image,
 							ImagePullPolicy: corev1.PullPolicy(imagePullPolicy),
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "webhook",
+									ContainerPort: names.WEBHOOK_PORT,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
 							Env: []corev1.EnvVar{
 								{
 									Name:  "MULTUS_IMAGE",
 									Value: addonsImages.Multus,
 								},
+								{
+									Name:  "MULTUS_IMAGE_ARCHITECTURES",
+									Value: strings.Join(addonsImages.MultusArchitectures, ","),
+								},
 								{
 									Name:  "LINUX_BRIDGE_IMAGE",
 									Value: addonsImages.LinuxBridgeCni,
 								},
+								{
+									Name:  "LINUX_BRIDGE_IMAGE_ARCHITECTURES",
+									Value: strings.Join(addonsImages.LinuxBridgeCniArchitectures, ","),
+								},
 								{
 									Name:  "LINUX_BRIDGE_MARKER_IMAGE",
 									Value: addonsImages.LinuxBridgeMarker,
 								},
+								{
+									Name:  "LINUX_BRIDGE_MARKER_IMAGE_ARCHITECTURES",
+									Value: strings.Join(addonsImages.LinuxBridgeMarkerArchitectures, ","),
+								},
 								{
 									Name:  "NMSTATE_HANDLER_IMAGE",
 									Value: addonsImages.NMStateHandler,
 								},
+								{
+									Name:  "NMSTATE_HANDLER_IMAGE_ARCHITECTURES",
+									Value: strings.Join(addonsImages.NMStateHandlerArchitectures, ","),
+								},
 								{
 									Name:  "OVS_CNI_IMAGE",
 									Value: addonsImages.OvsCni,
 								},
+								{
+									Name:  "OVS_CNI_IMAGE_ARCHITECTURES",
+									Value: strings.Join(addonsImages.OvsCniArchitectures, ","),
+								},
 								{
 									Name:  "OVS_MARKER_IMAGE",
 									Value: addonsImages.OvsMarker,
 								},
+								{
+									Name:  "OVS_MARKER_IMAGE_ARCHITECTURES",
+									Value: strings.Join(addonsImages.OvsMarkerArchitectures, ","),
+								},
 								{
 									Name:  "KUBEMACPOOL_IMAGE",
 									Value: addonsImages.KubeMacPool,
 								},
+								{
+									Name:  "KUBEMACPOOL_IMAGE_ARCHITECTURES",
+									Value: strings.Join(addonsImages.KubeMacPoolArchitectures, ","),
+								},
 								{
 									Name:  "OPERATOR_IMAGE",
 									Value: image,
@@ -305,7 +368,12 @@ func GetClusterRole() *rbacv1.ClusterRole {
 	return role
 }
 
-func GetCrd() *extv1beta1.CustomResourceDefinition {
+func GetCrd() (*extv1beta1.CustomResourceDefinition, error) {
+	validation, err := getCrdValidation()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build OpenAPI schema of NetworkAddonsConfig")
+	}
+
 	crd := &extv1beta1.CustomResourceDefinition{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "apiextensions.k8s.io/v1beta1",
@@ -316,7 +384,7 @@ func GetCrd() *extv1beta1.CustomResourceDefinition {
 		},
 		Spec: extv1beta1.CustomResourceDefinitionSpec{
 			Group:   "networkaddonsoperator.network.kubevirt.io",
-			Version: "v1alpha1",
+			Version: "v1",
 			Scope:   "Cluster",
 
 			Subresources: &extv1beta1.CustomResourceSubresources{
@@ -332,53 +400,44 @@ func GetCrd() *extv1beta1.CustomResourceDefinition {
 
 			Versions: []extv1beta1.CustomResourceDefinitionVersion{
 				{
-					Name:    "v1alpha1",
+					Name:    "v1",
 					Served:  true,
 					Storage: true,
 				},
+				{
+					Name:    "v1alpha1",
+					Served:  true,
+					Storage: false,
+				},
 			},
 
-			Validation: &extv1beta1.CustomResourceValidation{
-				OpenAPIV3Schema: &extv1beta1.JSONSchemaProps{
-					Properties: map[string]extv1beta1.JSONSchemaProps{
-						"apiVersion": extv1beta1.JSONSchemaProps{
-							Type: "string",
-						},
-						"kind": extv1beta1.JSONSchemaProps{
-							Type: "string",
-						},
-						"metadata": extv1beta1.JSONSchemaProps{
-							Type: "object",
-						},
-						"spec": extv1beta1.JSONSchemaProps{
-							Type: "object",
-						},
-						"status": extv1beta1.JSONSchemaProps{
-							Type: "object",
-						},
-					},
-				},
+			// Conversion webhook is registered by the operator once it is running. Until then,
+			// both versions share the same schema and can be converted by changing apiVersion.
+			Conversion: &extv1beta1.CustomResourceConversion{
+				Strategy: extv1beta1.NoneConverter,
 			},
+
+			Validation: validation,
 		},
 	}
-	return crd
+	return crd, nil
 }
 
-func GetCR() *cnav1alpha1.NetworkAddonsConfig {
-	return &cnav1alpha1.NetworkAddonsConfig{
+func GetCR() *opv1.NetworkAddonsConfig {
+	return &opv1.NetworkAddonsConfig{
 		TypeMeta: metav1.TypeMeta{
-			APIVersion: "networkaddonsoperator.network.kubevirt.io/v1alpha1",
+			APIVersion: "networkaddonsoperator.network.kubevirt.io/v1",
 			Kind:       "NetworkAddonsConfig",
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "cluster",
 		},
-		Spec: cnav1alpha1.NetworkAddonsConfigSpec{
-			Multus:          &cnav1alpha1.Multus{},
-			LinuxBridge:     &cnav1alpha1.LinuxBridge{},
-			KubeMacPool:     &cnav1alpha1.KubeMacPool{},
-			NMState:         &cnav1alpha1.NMState{},
-			Ovs:             &cnav1alpha1.Ovs{},
+		Spec: opv1.NetworkAddonsConfigSpec{
+			Multus:          &opv1.Multus{},
+			LinuxBridge:     &opv1.LinuxBridge{},
+			KubeMacPool:     &opv1.KubeMacPool{},
+			NMState:         &opv1.NMState{},
+			Ovs:             &opv1.Ovs{},
 			ImagePullPolicy: corev1.PullIfNotPresent,
 		},
 	}
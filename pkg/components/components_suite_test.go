@@ -0,0 +1,13 @@
+package components
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestComponents(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Components Suite")
+}
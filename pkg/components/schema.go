@@ -0,0 +1,308 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/go-openapi/spec"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/kube-openapi/pkg/common"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+// macAddressPattern matches MAC addresses in the format used by KubeMacPool, e.g. 02:00:00:00:00:00
+const macAddressPattern = `^([0-9A-Fa-f]{2}[:-]){5}[0-9A-Fa-f]{2}$`
+
+const configDefinition = "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1.NetworkAddonsConfig"
+
+// intOrStringFormat marks properties accepting both integers and strings, such as resource
+// quantities. Vendored apiextensions types do not have the x-kubernetes-int-or-string field yet,
+// the format is replaced by it once the CRD is converted to unstructured, see UnstructuredCrd
+const intOrStringFormat = "int-or-string"
+
+// coreDefinitionPrefixes select definitions of Kubernetes types referenced by the API, such as
+// Affinity or ResourceRequirements. They are taken from OpenAPI definitions generated by
+// Prometheus operator, which embeds them, since they are not vendored otherwise
+var coreDefinitionPrefixes = []string{"k8s.io/api/core/v1.", "k8s.io/apimachinery/pkg/apis/meta/v1."}
+
+// externalDefinitions are schemas of types referenced by the API which are not part of generated
+// OpenAPI definitions, or which are described better here than in the core definitions
+var externalDefinitions = map[string]extv1beta1.JSONSchemaProps{
+	"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta": {
+		Type: "object",
+	},
+	"k8s.io/apimachinery/pkg/apis/meta/v1.Time": {
+		Type:   "string",
+		Format: "date-time",
+	},
+	"k8s.io/apimachinery/pkg/apis/meta/v1.Duration": {
+		Description: "Duration in the format used by Go, e.g. 10m or 1h30m",
+		Type:        "string",
+	},
+	"k8s.io/apimachinery/pkg/api/resource.Quantity": {
+		Description: "Quantity is a fixed-point representation of a number, e.g. 100m or 1Gi",
+		Format:      intOrStringFormat,
+	},
+	"k8s.io/api/core/v1.Toleration": {
+		Description: "Toleration allows the pod to be scheduled on nodes with matching taints",
+		Type:        "object",
+		Properties: map[string]extv1beta1.JSONSchemaProps{
+			"key": {
+				Description: "Taint key that the toleration applies to, empty means all taint keys",
+				Type:        "string",
+			},
+			"operator": {
+				Description: "Operator represents a key's relationship to the value",
+				Type:        "string",
+				Enum:        enum(string(corev1.TolerationOpExists), string(corev1.TolerationOpEqual)),
+			},
+			"value": {
+				Description: "Taint value the toleration matches to",
+				Type:        "string",
+			},
+			"effect": {
+				Description: "Effect indicates the taint effect to match, empty means all taint effects",
+				Type:        "string",
+				Enum:        enum(string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectPreferNoSchedule), string(corev1.TaintEffectNoExecute)),
+			},
+			"tolerationSeconds": {
+				Description: "Period of time the toleration of a NoExecute taint lasts",
+				Type:        "integer",
+				Format:      "int64",
+			},
+		},
+	},
+	"github.com/openshift/custom-resource-status/conditions/v1.Condition": {
+		Description: "Condition represents the state of the operator's reconciliation functionality",
+		Type:        "object",
+		Properties: map[string]extv1beta1.JSONSchemaProps{
+			"type": {
+				Description: "Type of condition, e.g. Available, Progressing or Degraded",
+				Type:        "string",
+			},
+			"status": {
+				Description: "Status of the condition",
+				Type:        "string",
+				Enum:        enum(string(corev1.ConditionTrue), string(corev1.ConditionFalse), string(corev1.ConditionUnknown)),
+			},
+			"reason": {
+				Description: "One-word CamelCase reason for the condition's last transition",
+				Type:        "string",
+			},
+			"message": {
+				Description: "Human-readable message indicating details about last transition",
+				Type:        "string",
+			},
+			"lastHeartbeatTime": {
+				Description: "Last time we got an update on a given condition",
+				Type:        "string",
+				Format:      "date-time",
+			},
+			"lastTransitionTime": {
+				Description: "Last time the condition transit from one status to another",
+				Type:        "string",
+				Format:      "date-time",
+			},
+		},
+		Required: []string{"type", "status"},
+	},
+}
+
+// getCrdValidation returns OpenAPI v3 schema of NetworkAddonsConfig generated from its Go types
+// and amended by constraints which cannot be expressed in them
+func getCrdValidation() (*extv1beta1.CustomResourceValidation, error) {
+	ref := func(name string) spec.Ref {
+		return spec.MustCreateRef(name)
+	}
+	definitions := opv1.GetOpenAPIDefinitions(ref)
+	for name, definition := range monitoringv1.GetOpenAPIDefinitions(ref) {
+		if _, found := definitions[name]; !found && isCoreDefinition(name) {
+			definitions[name] = definition
+		}
+	}
+
+	schema, err := toJSONSchemaProps(definitions, definitions[configDefinition].Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	// Root of the schema may contain only properties when the status subresource is enabled
+	schema = extv1beta1.JSONSchemaProps{Properties: schema.Properties}
+
+	patches := []struct {
+		path  []string
+		patch func(*extv1beta1.JSONSchemaProps)
+	}{
+		{[]string{"spec", "imagePullPolicy"}, func(property *extv1beta1.JSONSchemaProps) {
+			property.Enum = enum(string(corev1.PullAlways), string(corev1.PullIfNotPresent), string(corev1.PullNever))
+		}},
+		{[]string{"spec", "kubeMacPool", "rangeStart"}, func(property *extv1beta1.JSONSchemaProps) {
+			property.Pattern = macAddressPattern
+		}},
+		{[]string{"spec", "kubeMacPool", "rangeEnd"}, func(property *extv1beta1.JSONSchemaProps) {
+			property.Pattern = macAddressPattern
+		}},
+	}
+	for _, patch := range patches {
+		if err := patchProperty(&schema, patch.path, patch.patch); err != nil {
+			return nil, err
+		}
+	}
+
+	return &extv1beta1.CustomResourceValidation{
+		OpenAPIV3Schema: &schema,
+	}, nil
+}
+
+func isCoreDefinition(name string) bool {
+	for _, prefix := range coreDefinitionPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// toJSONSchemaProps converts generated OpenAPI schema to the format used by CRDs, references to
+// other definitions are inlined, since they are not supported in CRDs
+func toJSONSchemaProps(definitions map[string]common.OpenAPIDefinition, schema spec.Schema) (extv1beta1.JSONSchemaProps, error) {
+	if ref := schema.Ref.String(); ref != "" {
+		props, err := resolveReference(definitions, ref)
+		if err != nil {
+			return extv1beta1.JSONSchemaProps{}, err
+		}
+		if schema.Description != "" {
+			props.Description = schema.Description
+		}
+		return props, nil
+	}
+
+	props := extv1beta1.JSONSchemaProps{
+		Description: schema.Description,
+		Format:      schema.Format,
+		Pattern:     schema.Pattern,
+	}
+	if len(schema.Type) > 0 {
+		props.Type = schema.Type[0]
+	}
+
+	if schema.Properties != nil {
+		props.Type = "object"
+		props.Properties = map[string]extv1beta1.JSONSchemaProps{}
+		for name, property := range schema.Properties {
+			propertyProps, err := toJSONSchemaProps(definitions, property)
+			if err != nil {
+				return extv1beta1.JSONSchemaProps{}, errors.Wrapf(err, "property %q", name)
+			}
+			props.Properties[name] = propertyProps
+		}
+		if len(schema.Required) > 0 {
+			props.Required = append([]string{}, schema.Required...)
+		}
+	}
+
+	if schema.Items != nil && schema.Items.Schema != nil {
+		items, err := toJSONSchemaProps(definitions, *schema.Items.Schema)
+		if err != nil {
+			return extv1beta1.JSONSchemaProps{}, err
+		}
+		props.Items = &extv1beta1.JSONSchemaPropsOrArray{Schema: &items}
+	}
+
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		additionalProperties, err := toJSONSchemaProps(definitions, *schema.AdditionalProperties.Schema)
+		if err != nil {
+			return extv1beta1.JSONSchemaProps{}, err
+		}
+		props.AdditionalProperties = &extv1beta1.JSONSchemaPropsOrBool{Allows: true, Schema: &additionalProperties}
+	}
+
+	return props, nil
+}
+
+func resolveReference(definitions map[string]common.OpenAPIDefinition, ref string) (extv1beta1.JSONSchemaProps, error) {
+	if props, found := externalDefinitions[ref]; found {
+		return *props.DeepCopy(), nil
+	}
+	if definition, found := definitions[ref]; found {
+		return toJSONSchemaProps(definitions, definition.Schema)
+	}
+	return extv1beta1.JSONSchemaProps{}, errors.Errorf("no OpenAPI definition found for %q", ref)
+}
+
+// patchProperty modifies nested property of the schema found on given path
+func patchProperty(schema *extv1beta1.JSONSchemaProps, path []string, patch func(*extv1beta1.JSONSchemaProps)) error {
+	if len(path) == 0 {
+		patch(schema)
+		return nil
+	}
+
+	property, found := schema.Properties[path[0]]
+	if !found {
+		return errors.Errorf("property %q not found in the schema", path[0])
+	}
+	if err := patchProperty(&property, path[1:], patch); err != nil {
+		return err
+	}
+	schema.Properties[path[0]] = property
+	return nil
+}
+
+// UnstructuredCrd converts the CRD to unstructured, so it can carry schema fields which are not
+// known to vendored apiextensions types. Properties marked by intOrStringFormat are turned into
+// x-kubernetes-int-or-string, so both integers and strings pass validation of a structural schema
+func UnstructuredCrd(crd *extv1beta1.CustomResourceDefinition) (*unstructured.Unstructured, error) {
+	data, err := json.Marshal(crd)
+	if err != nil {
+		return nil, err
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	if schema, found, _ := unstructured.NestedMap(obj, "spec", "validation", "openAPIV3Schema"); found {
+		markIntOrString(schema)
+		if err := unstructured.SetNestedMap(obj, schema, "spec", "validation", "openAPIV3Schema"); err != nil {
+			return nil, err
+		}
+	}
+
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+// markIntOrString replaces intOrStringFormat by x-kubernetes-int-or-string in the schema and all
+// its nested schemas
+func markIntOrString(schema map[string]interface{}) {
+	if schema["format"] == intOrStringFormat {
+		delete(schema, "format")
+		schema["x-kubernetes-int-or-string"] = true
+	}
+
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		for _, property := range properties {
+			if property, ok := property.(map[string]interface{}); ok {
+				markIntOrString(property)
+			}
+		}
+	}
+	for _, field := range []string{"items", "additionalProperties"} {
+		if nested, ok := schema[field].(map[string]interface{}); ok {
+			markIntOrString(nested)
+		}
+	}
+}
+
+func enum(values ...string) []extv1beta1.JSON {
+	enum := []extv1beta1.JSON{}
+	for _, value := range values {
+		enum = append(enum, extv1beta1.JSON{Raw: []byte(fmt.Sprintf("%q", value))})
+	}
+	return enum
+}
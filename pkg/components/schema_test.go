@@ -0,0 +1,115 @@
+package components
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Testing CRD schema", func() {
+	var schema *extv1beta1.JSONSchemaProps
+
+	BeforeEach(func() {
+		crd, err := GetCrd()
+		Expect(err).NotTo(HaveOccurred())
+		schema = crd.Spec.Validation.OpenAPIV3Schema
+	})
+
+	property := func(path ...string) extv1beta1.JSONSchemaProps {
+		props := *schema
+		for _, name := range path {
+			Expect(props.Properties).To(HaveKey(name), "property %v not found in the schema", path)
+			props = props.Properties[name]
+		}
+		return props
+	}
+
+	It("should contain only properties at the root", func() {
+		Expect(schema.Type).To(BeEmpty())
+		Expect(schema.Properties).To(HaveKey("spec"))
+		Expect(schema.Properties).To(HaveKey("status"))
+	})
+
+	It("should describe all components in spec", func() {
+		for _, component := range []string{"multus", "linuxBridge", "ovs", "kubeMacPool", "nmstate"} {
+			Expect(property("spec", component).Type).To(Equal("object"))
+			Expect(property("spec", component).Description).NotTo(BeEmpty())
+		}
+	})
+
+	It("should describe placement of all components", func() {
+		Expect(property("spec", "placement", "nodeSelector").AdditionalProperties.Schema.Type).To(Equal("string"))
+		for _, component := range []string{"multus", "linuxBridge", "ovs", "kubeMacPool", "nmstate"} {
+			placement := property("spec", component, "placement")
+			Expect(placement.Properties).To(HaveKey("nodeSelector"))
+			Expect(placement.Properties).To(HaveKey("affinity"))
+			Expect(placement.Properties["tolerations"].Items.Schema.Properties).To(HaveKey("effect"))
+		}
+	})
+
+	It("should describe affinity by the core type", func() {
+		affinity := property("spec", "placement", "affinity")
+		Expect(affinity.Properties).To(HaveKey("nodeAffinity"))
+		Expect(affinity.Properties).To(HaveKey("podAntiAffinity"))
+		terms := affinity.Properties["nodeAffinity"].Properties["requiredDuringSchedulingIgnoredDuringExecution"].Properties["nodeSelectorTerms"]
+		Expect(terms.Items.Schema.Properties["matchExpressions"].Items.Schema.Properties).To(HaveKey("operator"))
+	})
+
+	It("should describe resources of all components", func() {
+		for _, component := range []string{"multus", "linuxBridge", "ovs", "kubeMacPool", "nmstate"} {
+			resources := property("spec", component, "resources")
+			Expect(resources.Type).To(Equal("object"))
+			for _, field := range []string{"limits", "requests"} {
+				quantities := resources.AdditionalProperties.Schema.Properties[field]
+				Expect(quantities.Type).To(Equal("object"))
+				Expect(quantities.AdditionalProperties.Schema.Format).To(Equal(intOrStringFormat))
+			}
+		}
+	})
+
+	It("should accept both integers and strings as quantities of the unstructured CRD", func() {
+		crd, err := GetCrd()
+		Expect(err).NotTo(HaveOccurred())
+		obj, err := UnstructuredCrd(crd)
+		Expect(err).NotTo(HaveOccurred())
+
+		quantity, found, err := unstructured.NestedMap(obj.Object, "spec", "validation", "openAPIV3Schema", "properties", "spec", "properties", "multus", "properties", "resources", "additionalProperties", "properties", "limits", "additionalProperties")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(quantity).To(HaveKeyWithValue("x-kubernetes-int-or-string", true))
+		Expect(quantity).NotTo(HaveKey("format"))
+		Expect(quantity).NotTo(HaveKey("type"))
+	})
+
+	It("should limit imagePullPolicy to known values", func() {
+		Expect(property("spec", "imagePullPolicy").Enum).To(ConsistOf(
+			extv1beta1.JSON{Raw: []byte(`"Always"`)},
+			extv1beta1.JSON{Raw: []byte(`"IfNotPresent"`)},
+			extv1beta1.JSON{Raw: []byte(`"Never"`)},
+		))
+	})
+
+	It("should describe status conditions", func() {
+		conditions := property("status", "conditions")
+		Expect(conditions.Type).To(Equal("array"))
+		Expect(conditions.Items.Schema.Required).To(ConsistOf("type", "status"))
+		Expect(conditions.Items.Schema.Properties["lastTransitionTime"].Format).To(Equal("date-time"))
+	})
+
+	DescribeTable("KubeMacPool range pattern",
+		func(mac string, matches bool) {
+			for _, field := range []string{"rangeStart", "rangeEnd"} {
+				pattern := regexp.MustCompile(property("spec", "kubeMacPool", field).Pattern)
+				Expect(pattern.MatchString(mac)).To(Equal(matches))
+			}
+		},
+		Entry("should accept colon separated address", "02:00:00:00:00:00", true),
+		Entry("should accept dash separated address in upper case", "FD-FF-FF-FF-FF-FF", true),
+		Entry("should reject too short address", "02:00:00:00:00", false),
+		Entry("should reject address with invalid characters", "this:aint:right", false),
+	)
+})
@@ -0,0 +1,144 @@
+package networkaddonsconfig
+
+import (
+	"context"
+	"log"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/network"
+)
+
+// watchOwnedObjects makes sure that NetworkAddonsConfig is reconciled whenever an object deployed
+// for it is modified or removed, so the object is restored. Only objects cached by the given
+// informers are watched, see newOwnedObjectInformers
+func watchOwnedObjects(c controller.Controller, informers []cache.SharedIndexInformer) error {
+	for _, informer := range informers {
+		if err := c.Watch(&source.Informer{Informer: informer}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(ownerConfigRequests)}, driftPredicate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newOwnedObjectInformers returns an informer per owned kind, see network.OwnedKinds. Each of them
+// lists only objects labeled by network.OwnedLabel, i.e. objects rendered by the operator, in all
+// namespaces
+func newOwnedObjectInformers(client dynamic.Interface, mapper meta.RESTMapper, clusterInfo *network.ClusterInfo) ([]cache.SharedIndexInformer, error) {
+	informers := []cache.SharedIndexInformer{}
+	for _, gvk := range network.OwnedKinds(clusterInfo) {
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find resource of %s", gvk)
+		}
+		resource := client.Resource(mapping.Resource).Namespace(metav1.NamespaceAll)
+
+		informers = append(informers, cache.NewSharedIndexInformer(
+			&cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					options.LabelSelector = network.OwnedLabel
+					return resource.List(options)
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					options.LabelSelector = network.OwnedLabel
+					return resource.Watch(options)
+				},
+			},
+			&unstructured.Unstructured{},
+			0,
+			cache.Indexers{},
+		))
+	}
+	return informers, nil
+}
+
+// ownerConfigRequests finds NetworkAddonsConfig controlling the object. Unlike handler.EnqueueRequestForOwner,
+// it does not expect the owner to live in the same namespace, NetworkAddonsConfig is cluster-scoped
+func ownerConfigRequests(obj handler.MapObject) []reconcile.Request {
+	owner := metav1.GetControllerOf(obj.Meta)
+	if owner == nil || owner.Kind != "NetworkAddonsConfig" {
+		return nil
+	}
+	ownerGV, err := schema.ParseGroupVersion(owner.APIVersion)
+	if err != nil || ownerGV.Group != opv1.SchemeGroupVersion.Group {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: owner.Name}}}
+}
+
+// driftPredicate ignores creation of objects, which is done by the operator, and updates which
+// change only their status or metadata maintained by the apiserver
+var driftPredicate = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return false
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return !equality.Semantic.DeepEqual(withoutStatus(e.ObjectOld), withoutStatus(e.ObjectNew))
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		return true
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return false
+	},
+}
+
+// withoutStatus returns a copy of the object without fields which are updated by the cluster on
+// its own. Objects which are not unstructured are compared as they are
+func withoutStatus(obj runtime.Object) runtime.Object {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return obj
+	}
+	u = u.DeepCopy()
+	unstructured.RemoveNestedField(u.Object, "status")
+	unstructured.RemoveNestedField(u.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(u.Object, "metadata", "generation")
+	unstructured.RemoveNestedField(u.Object, "metadata", "managedFields")
+	return u
+}
+
+// missingObjects finds objects which were applied before, as recorded in the inventory, but do not
+// exist anymore. Those were removed by somebody else and are about to be restored
+func (r *ReconcileNetworkAddonsConfig) missingObjects(networkAddonsConfig *opv1.NetworkAddonsConfig) (map[string]bool, error) {
+	inventory, err := getAppliedInventory(context.TODO(), r.client, networkAddonsConfig.Name, r.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := map[string]bool{}
+	for _, item := range inventory {
+		obj := item.Object()
+		err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, obj)
+		if apierrors.IsNotFound(err) {
+			missing[apply.ObjectKey(obj)] = true
+		}
+	}
+	return missing, nil
+}
+
+// reportRestored records an event about an object which was removed by somebody and restored
+func (r *ReconcileNetworkAddonsConfig) reportRestored(obj *unstructured.Unstructured) {
+	log.Printf("(%s) %s/%s was removed, restored it", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+	r.recorder.Eventf(obj, corev1.EventTypeWarning, "Drifted", "%s %s was removed, it was restored", obj.GetKind(), obj.GetName())
+}
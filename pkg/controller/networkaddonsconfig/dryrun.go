@@ -0,0 +1,135 @@
+package networkaddonsconfig
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/controller/statusmanager"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/names"
+	k8sutil "github.com/kubevirt/cluster-network-addons-operator/pkg/util/k8s"
+)
+
+// reconcileDryRun finds out which objects would be created, updated or deleted for the config and
+// reports them in a ConfigMap. Deployed components are left untouched
+func (r *ReconcileNetworkAddonsConfig) reconcileDryRun(networkAddonsConfig *opv1.NetworkAddonsConfig, objs, removedObjs []*unstructured.Unstructured) (reconcile.Result, error) {
+	changes, err := r.planChanges(networkAddonsConfig, objs, removedObjs)
+	if err != nil {
+		log.Printf("failed to plan changes: %v", err)
+		err = errors.Wrap(err, "failed to plan changes")
+		r.statusManager.SetFailing(statusmanager.OperatorConfig, "FailedToPlan", err.Error())
+		return reconcile.Result{}, err
+	}
+
+	report, err := dryRunReport(networkAddonsConfig, changes, r.namespace)
+	if err == nil {
+		err = r.setControllerReference(networkAddonsConfig, report)
+	}
+	if err == nil {
+		_, err = apply.ApplyObject(context.TODO(), r.client, r.recorder, report)
+	}
+	if err != nil {
+		log.Printf("failed to report planned changes: %v", err)
+		err = errors.Wrap(err, "failed to report planned changes")
+		r.statusManager.SetFailing(statusmanager.OperatorConfig, "FailedToPlan", err.Error())
+		return reconcile.Result{}, err
+	}
+	log.Printf("dry run, %d planned changes were reported in ConfigMap %s/%s", len(changes), report.GetNamespace(), report.GetName())
+
+	r.statusManager.SetNotFailing(statusmanager.OperatorConfig)
+
+	return reconcile.Result{}, nil
+}
+
+// planChanges lists changes which would be done by a regular reconcile, removals first, followed by
+// the rest in the order in which objects are applied
+func (r *ReconcileNetworkAddonsConfig) planChanges(networkAddonsConfig *opv1.NetworkAddonsConfig, objs, removedObjs []*unstructured.Unstructured) ([]apply.PlannedChange, error) {
+	changes := []apply.PlannedChange{}
+
+	for _, obj := range removedObjs {
+		change, err := apply.PlanRemoval(context.TODO(), r.client, obj)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	for _, stage := range r.applyStages(networkAddonsConfig, objs) {
+		for _, obj := range stage.Objects {
+			if err := r.setControllerReference(networkAddonsConfig, obj); err != nil {
+				return nil, err
+			}
+			change, err := apply.PlanObject(context.TODO(), r.client, obj)
+			if err != nil {
+				return nil, err
+			}
+			if change != nil {
+				changes = append(changes, *change)
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// dryRunReport renders the ConfigMap listing planned changes, both as a human readable summary
+// and as a JSON list
+func dryRunReport(networkAddonsConfig *opv1.NetworkAddonsConfig, changes []apply.PlannedChange, namespace string) (*unstructured.Unstructured, error) {
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := []string{}
+	for _, change := range changes {
+		summary = append(summary, change.String())
+	}
+
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.DRY_RUN_REPORT_PREFIX + networkAddonsConfig.Name,
+			Namespace: namespace,
+		},
+		Data: map[string]string{
+			"summary": strings.Join(summary, "\n"),
+			"changes": string(data),
+		},
+	}
+
+	return k8sutil.ToUnstructured(cm)
+}
+
+// removeDryRunReport removes the report of changes planned in dry run mode, it is outdated once
+// the changes are applied
+func (r *ReconcileNetworkAddonsConfig) removeDryRunReport(networkAddonsConfig *opv1.NetworkAddonsConfig) error {
+	report := &corev1.ConfigMap{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: names.DRY_RUN_REPORT_PREFIX + networkAddonsConfig.Name, Namespace: r.namespace}, report)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err == nil {
+		err = r.client.Delete(context.TODO(), report)
+	}
+	if err != nil && !apierrors.IsNotFound(err) {
+		log.Printf("failed to remove report of planned changes: %v", err)
+		return errors.Wrap(err, "failed to remove report of planned changes")
+	}
+	return nil
+}
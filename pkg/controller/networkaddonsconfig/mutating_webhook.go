@@ -0,0 +1,79 @@
+package networkaddonsconfig
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	atypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/types"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/names"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/network"
+)
+
+// newMutatingWebhook returns a webhook storing defaults of NetworkAddonsConfig, e.g. the generated
+// KubeMacPool range, in the object itself
+func newMutatingWebhook(client client.Client, scheme *runtime.Scheme, namespace string, clusterInfo *network.ClusterInfo) *admission.Webhook {
+	// If the operator is not running, defaults are written back to the config by the reconcile loop
+	failurePolicy := admissionregistrationv1beta1.Ignore
+
+	return &admission.Webhook{
+		Name:          "default.networkaddonsconfigs." + opv1.SchemeGroupVersion.Group,
+		Type:          types.WebhookTypeMutating,
+		Rules:         configRules(),
+		FailurePolicy: &failurePolicy,
+		Handlers: []admission.Handler{
+			&configDefaulter{client: client, scheme: scheme, codecs: serializer.NewCodecFactory(scheme), namespace: namespace, clusterInfo: clusterInfo},
+		},
+	}
+}
+
+// configDefaulter fills in defaults of NetworkAddonsConfig the same way the reconcile loop does,
+// so the effective configuration is visible in the stored object
+type configDefaulter struct {
+	client      client.Client
+	scheme      *runtime.Scheme
+	codecs      serializer.CodecFactory
+	namespace   string
+	clusterInfo *network.ClusterInfo
+}
+
+var _ admission.Handler = &configDefaulter{}
+
+func (d *configDefaulter) Handle(ctx context.Context, req atypes.Request) atypes.Response {
+	networkAddonsConfig, err := decodeConfig(d.codecs, req.AdmissionRequest.Object.Raw)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+
+	// Configs with other than the default name are ignored by the operator
+	if networkAddonsConfig.Name != names.OPERATOR_CONFIG {
+		return admission.ValidationResponse(true, "")
+	}
+
+	defaulted := networkAddonsConfig.DeepCopy()
+	if _, _, err := defaultConfig(ctx, d.client, defaulted, d.namespace, d.clusterInfo); err != nil {
+		// Leave the config untouched, the validating webhook will reject it with the same error
+		log.Printf("not filling defaults of invalid NetworkAddonsConfig: %v", err)
+		return admission.ValidationResponse(true, "")
+	}
+
+	// The patch has to be computed against the object in the version it was sent in
+	original, _, err := d.codecs.UniversalDeserializer().Decode(req.AdmissionRequest.Object.Raw, nil, nil)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+	converted, err := d.scheme.ConvertToVersion(defaulted, original.GetObjectKind().GroupVersionKind().GroupVersion())
+	if err != nil {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponse(original, converted)
+}
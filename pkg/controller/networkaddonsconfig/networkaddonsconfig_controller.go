@@ -7,16 +7,24 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"time"
 
 	osv1 "github.com/openshift/api/operator/v1"
 	osnetnames "github.com/openshift/cluster-network-operator/pkg/names"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
@@ -29,15 +37,31 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	opv1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
 	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/components"
 	"github.com/kubevirt/cluster-network-addons-operator/pkg/controller/statusmanager"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/metrics"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/migration"
 	"github.com/kubevirt/cluster-network-addons-operator/pkg/names"
 	"github.com/kubevirt/cluster-network-addons-operator/pkg/network"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/webhook"
 )
 
-// ManifestPath is the path to the manifest templates
-const ManifestPath = "./data"
+// ManifestPath is the path to the manifest templates. It is empty unless
+// overridden by MANIFEST_DIR, meaning templates compiled into the binary are used
+var ManifestPath string
+
+// removalCheckInterval is how often the operator checks whether objects of removed components are gone
+const removalCheckInterval = 5 * time.Second
+
+// migrationCheckInterval is how often the operator checks whether replacements of objects handled
+// by a pending migration are rolled out
+const migrationCheckInterval = 10 * time.Second
+
+// stageCheckInterval is how often the operator checks whether objects of an applied stage, such as
+// namespaces and CRDs, are ready, so the following stages can be applied
+const stageCheckInterval = 2 * time.Second
 
 var operatorNamespace string
 var operatorVersion string
@@ -45,6 +69,7 @@ var operatorVersion string
 func init() {
 	operatorNamespace = os.Getenv("OPERATOR_NAMESPACE")
 	operatorVersion = os.Getenv("OPERATOR_VERSION")
+	ManifestPath = os.Getenv("MANIFEST_DIR")
 }
 
 // Add creates a new NetworkAddonsConfig Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -60,6 +85,10 @@ func Add(mgr manager.Manager) error {
 		return fmt.Errorf("failed to initialize apiserver client: %v", err)
 	}
 
+	if ManifestPath != "" {
+		log.Printf("using manifest templates from %s instead of the embedded ones", ManifestPath)
+	}
+
 	namespace, namespaceSet := os.LookupEnv("OPERATOR_NAMESPACE")
 	if !namespaceSet {
 		return fmt.Errorf("environment variable OPERATOR_NAMESPACE has to be set")
@@ -82,22 +111,91 @@ func Add(mgr manager.Manager) error {
 	}
 	clusterInfo.SCCAvailable = sccAvailable
 
-	return add(mgr, newReconciler(mgr, namespace, clusterInfo))
+	monitoringAvailable, err := isMonitoringAvailable(clientset)
+	if err != nil {
+		return fmt.Errorf("failed to check for availability of Prometheus operator: %v", err)
+	}
+	clusterInfo.MonitoringAvailable = monitoringAvailable
+
+	extClientset, err := apiextensionsclient.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize apiextensions client: %v", err)
+	}
+
+	if err := addWebhooks(mgr, extClientset, namespace, clusterInfo); err != nil {
+		return fmt.Errorf("failed to set up webhooks: %v", err)
+	}
+
+	// Pods of components are cached by an informer of their own, restricted by their label.
+	// Otherwise the cache of the manager would hold all pods of the cluster
+	podInformer := newComponentPodInformer(clientset)
+	if err := mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+		podInformer.Run(stop)
+		return nil
+	})); err != nil {
+		return fmt.Errorf("failed to set up informer of component pods: %v", err)
+	}
+
+	// The same goes for objects rendered by the operator, only those labeled as owned are cached
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize dynamic apiserver client: %v", err)
+	}
+	ownedInformers, err := newOwnedObjectInformers(dynamicClient, mgr.GetRESTMapper(), clusterInfo)
+	if err != nil {
+		return fmt.Errorf("failed to set up informers of owned objects: %v", err)
+	}
+	for _, informer := range ownedInformers {
+		informer := informer
+		if err := mgr.Add(manager.RunnableFunc(func(stop <-chan struct{}) error {
+			informer.Run(stop)
+			return nil
+		})); err != nil {
+			return fmt.Errorf("failed to set up informers of owned objects: %v", err)
+		}
+	}
+
+	return add(mgr, newReconciler(mgr, namespace, clusterInfo, podInformer, ownedInformers))
+}
+
+// addWebhooks registers webhooks defaulting, validating and converting NetworkAddonsConfig in a webhook server run by the Manager
+func addWebhooks(mgr manager.Manager, extClientset apiextensionsclient.Interface, namespace string, clusterInfo *network.ClusterInfo) error {
+	server := webhook.NewServer(mgr.GetClient(), extClientset.ApiextensionsV1beta1().CustomResourceDefinitions(), namespace)
+
+	if err := server.Register(newMutatingWebhook(mgr.GetClient(), mgr.GetScheme(), namespace, clusterInfo)); err != nil {
+		return err
+	}
+
+	if err := server.Register(newValidatingWebhook(mgr.GetClient(), mgr.GetScheme(), namespace, clusterInfo)); err != nil {
+		return err
+	}
+
+	crd, err := components.GetCrd()
+	if err != nil {
+		return err
+	}
+	server.RegisterConversion(webhook.NewConversionWebhook(crd.Name, mgr.GetScheme()))
+
+	return mgr.Add(server)
 }
 
 // newReconciler returns a new ReconcileNetworkAddonsConfig
-func newReconciler(mgr manager.Manager, namespace string, clusterInfo *network.ClusterInfo) *ReconcileNetworkAddonsConfig {
+func newReconciler(mgr manager.Manager, namespace string, clusterInfo *network.ClusterInfo, podInformer cache.SharedIndexInformer, ownedInformers []cache.SharedIndexInformer) *ReconcileNetworkAddonsConfig {
 	// Status manager is shared between both reconcilers and it is used to update conditions of
 	// NetworkAddonsConfig.State. NetworkAddonsConfig reconciler updates it with progress of rendering
 	// and applying of manifests. Pods reconciler updates it with progress of deployed pods.
-	statusManager := statusmanager.New(mgr.GetClient(), names.OPERATOR_CONFIG)
+	recorder := mgr.GetRecorder(names.OPERATOR_COMPONENT)
+	statusManager := statusmanager.New(mgr.GetClient(), corelisters.NewPodLister(podInformer.GetIndexer()), recorder, names.OPERATOR_CONFIG)
 	return &ReconcileNetworkAddonsConfig{
-		client:        mgr.GetClient(),
-		scheme:        mgr.GetScheme(),
-		namespace:     namespace,
-		podReconciler: newPodReconciler(statusManager),
-		statusManager: statusManager,
-		clusterInfo:   clusterInfo,
+		client:         mgr.GetClient(),
+		scheme:         mgr.GetScheme(),
+		recorder:       recorder,
+		namespace:      namespace,
+		podReconciler:  newPodReconciler(statusManager),
+		statusManager:  statusManager,
+		clusterInfo:    clusterInfo,
+		podInformer:    podInformer,
+		ownedInformers: ownedInformers,
 	}
 }
 
@@ -111,7 +209,7 @@ func add(mgr manager.Manager, r *ReconcileNetworkAddonsConfig) error {
 
 	// Create custom predicate for NetworkAddonsConfig watcher. This makes sure that Status field
 	// updates will not trigger reconciling of the object. Reconciliation is trigger only if
-	// Spec fields differ.
+	// Spec fields differ or when the object is being deleted.
 	pred := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
 			oldConfig, err := runtimeObjectToNetworkAddonsConfig(e.ObjectOld)
@@ -124,12 +222,21 @@ func add(mgr manager.Manager, r *ReconcileNetworkAddonsConfig) error {
 				log.Printf("Failed to convert runtime.Object to NetworkAddonsConfig: %v", err)
 				return false
 			}
+			// Deletion of the config has to be handled too, teardown of components is waiting for it
+			if oldConfig.DeletionTimestamp.IsZero() != newConfig.DeletionTimestamp.IsZero() {
+				return true
+			}
 			return !reflect.DeepEqual(oldConfig.Spec, newConfig.Spec)
 		},
 	}
 
 	// Watch for changes to primary resource NetworkAddonsConfig
-	if err := c.Watch(&source.Kind{Type: &opv1alpha1.NetworkAddonsConfig{}}, &handler.EnqueueRequestForObject{}, pred); err != nil {
+	if err := c.Watch(&source.Kind{Type: &opv1.NetworkAddonsConfig{}}, &handler.EnqueueRequestForObject{}, pred); err != nil {
+		return err
+	}
+
+	// Watch for changes to objects deployed for NetworkAddonsConfig, so they are restored
+	if err := watchOwnedObjects(c, r.ownedInformers); err != nil {
 		return err
 	}
 
@@ -149,6 +256,13 @@ func add(mgr manager.Manager, r *ReconcileNetworkAddonsConfig) error {
 		return err
 	}
 
+	// Watch for changes on pods of DaemonSets and Deployments, so containers which fail to start
+	// are reported right away. Only pods of components are watched, see newComponentPodInformer
+	err = c.Watch(&source.Informer{Informer: r.podInformer}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(podWorkloadRequests)})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -160,10 +274,14 @@ type ReconcileNetworkAddonsConfig struct {
 	// that reads objects from the cache and writes to the apiserver
 	client        client.Client
 	scheme        *runtime.Scheme
+	recorder      record.EventRecorder
 	namespace     string
 	podReconciler *ReconcilePods
 	statusManager *statusmanager.StatusManager
 	clusterInfo   *network.ClusterInfo
+	podInformer   cache.SharedIndexInformer
+	// Informers of objects of owned kinds labeled by network.OwnedLabel
+	ownedInformers []cache.SharedIndexInformer
 }
 
 // Reconcile reads that state of the cluster for a NetworkAddonsConfig object and makes changes based on the state read
@@ -176,42 +294,112 @@ func (r *ReconcileNetworkAddonsConfig) Reconcile(request reconcile.Request) (rec
 		log.Print("ignoring NetworkAddonsConfig without default name")
 		return reconcile.Result{}, nil
 	}
+	defer metrics.ObserveReconcile(time.Now())
 
 	// Fetch the NetworkAddonsConfig instance
-	networkAddonsConfig := &opv1alpha1.NetworkAddonsConfig{}
+	networkAddonsConfig := &opv1.NetworkAddonsConfig{}
 	err := r.client.Get(context.TODO(), request.NamespacedName, networkAddonsConfig)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			// Request object not found, could have been deleted after reconcile request.
+			// Request object not found, it was deleted after its components were torn down.
 			// Reset list of tracked objects.
-			// TODO: This can be dropped once we implement a finalizer waiting for all components to be removed
 			r.trackDeployedObjects([]*unstructured.Unstructured{})
+			r.statusManager.SetTornDown()
 
-			// Owned objects are automatically garbage collected. Return and don't requeue
+			// Return and don't requeue
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
 
+	// The config is being deleted, tear down its components before letting it go
+	if !networkAddonsConfig.DeletionTimestamp.IsZero() {
+		return r.reconcileDeletion(networkAddonsConfig)
+	}
+
+	// Keep the Spec as it was stored, so we can find out whether defaults were filled in
+	storedSpec := networkAddonsConfig.Spec.DeepCopy()
+
 	// Canonicalize and validate NetworkAddonsConfig, finally render objects of requested components
-	objs, err := r.renderObjects(networkAddonsConfig)
+	objs, removedObjs, err := r.renderObjects(networkAddonsConfig)
 	if err != nil {
+		if _, invalid := err.(invalidConfigError); invalid {
+			metrics.ReportReconcileError(metrics.PhaseValidate)
+		} else {
+			metrics.ReportReconcileError(metrics.PhaseRender)
+		}
 		// If failed, set NetworkAddonsConfig to failing and requeue
 		r.statusManager.SetFailing(statusmanager.OperatorConfig, "FailedToRender", err.Error())
 		return reconcile.Result{}, err
 	}
 
+	// In dry run mode, only report what would be changed on the cluster
+	if networkAddonsConfig.Spec.DryRun {
+		return r.reconcileDryRun(networkAddonsConfig, objs, removedObjs)
+	}
+
+	// Defaults are normally filled in by the mutating webhook. If it was not available when the
+	// config was stored, write them back now, so the effective configuration is visible in the Spec
+	if !reflect.DeepEqual(storedSpec, &networkAddonsConfig.Spec) {
+		log.Print("storing defaults of NetworkAddonsConfig")
+		if err := r.client.Update(context.TODO(), networkAddonsConfig); err != nil {
+			log.Printf("failed to store defaults of NetworkAddonsConfig: %v", err)
+			err = errors.Wrap(err, "failed to store defaults of NetworkAddonsConfig")
+			r.statusManager.SetFailing(statusmanager.OperatorConfig, "FailedToStoreDefaults", err.Error())
+			return reconcile.Result{}, err
+		}
+	}
+
+	// Make sure that components are torn down in order once the config is deleted
+	if err := r.ensureFinalizer(networkAddonsConfig); err != nil {
+		r.statusManager.SetFailing(statusmanager.OperatorConfig, "FailedToAddFinalizer", err.Error())
+		return reconcile.Result{}, err
+	}
+
+	// Remove objects of components which are not requested anymore and objects which are not
+	// rendered anymore. This has to be done before the new configuration and inventory are
+	// recorded as applied, otherwise they would be forgotten on failure
+	r.statusManager.AddRemovedObjects(removedObjs)
+	err = r.removeObjects(removedObjs)
+	if err != nil {
+		metrics.ReportReconcileError(metrics.PhaseApply)
+		r.statusManager.SetFailing(statusmanager.OperatorConfig, "FailedToRemove", err.Error())
+		return reconcile.Result{}, err
+	}
+
 	// Apply generated objects on Kubernetes API server
-	err = r.applyObjects(networkAddonsConfig, objs)
+	changed, pendingStage, err := r.applyObjects(networkAddonsConfig, objs)
 	if err != nil {
+		metrics.ReportReconcileError(metrics.PhaseApply)
 		// If failed, set NetworkAddonsConfig to failing and requeue
 		r.statusManager.SetFailing(statusmanager.OperatorConfig, "FailedToApply", err.Error())
 		return reconcile.Result{}, err
 	}
 
-	// Track state of all deployed pods
+	// Most reconciliations don't change anything, only report those which did
+	if changed > 0 {
+		r.recorder.Eventf(networkAddonsConfig, corev1.EventTypeNormal, "Applied", "Applied %d changed objects of requested components", changed)
+	}
+
+	// Objects of following stages depend on the ones applied now, e.g. custom resources on their
+	// CRD. Don't block the worker while they become ready, check them again later instead
+	if pendingStage != "" {
+		log.Printf("%s are not ready yet, remaining stages will be applied later", pendingStage)
+		return reconcile.Result{RequeueAfter: stageCheckInterval}, nil
+	}
+
+	// Track state of all deployed pods, rollouts exceeding deadlines of their components are
+	// reported as Degraded
+	r.statusManager.SetProgressDeadlines(network.ProgressDeadlines(&networkAddonsConfig.Spec))
 	r.trackDeployedObjects(objs)
+	r.statusManager.SetPatches(network.AppliedPatches(&networkAddonsConfig.Spec))
+
+	// Changes planned in dry run mode, if any, were done now
+	if err := r.removeDryRunReport(networkAddonsConfig); err != nil {
+		r.statusManager.SetFailing(statusmanager.OperatorConfig, "FailedToRemove", err.Error())
+		return reconcile.Result{}, err
+	}
 
 	// Everything went smooth, remove failures from NetworkAddonsConfig if there are any from
 	// previous runs.
@@ -223,46 +411,251 @@ func (r *ReconcileNetworkAddonsConfig) Reconcile(request reconcile.Request) (rec
 	// perform the first check manually.
 	r.statusManager.SetFromPods()
 
+	// Deletion of some objects, e.g. namespaces, takes a while and there is no watch on them that
+	// would trigger reconciliation once they are gone. Check them periodically instead
+	if r.statusManager.IsRemoving() {
+		return reconcile.Result{RequeueAfter: removalCheckInterval}, nil
+	}
+
+	// The same goes for migrations waiting until their replacements are rolled out
+	waiting, err := r.migrationsWaiting(networkAddonsConfig)
+	if err != nil {
+		log.Printf("failed to check pending migrations: %v", err)
+		return reconcile.Result{}, errors.Wrap(err, "failed to check pending migrations")
+	}
+	if waiting {
+		return reconcile.Result{RequeueAfter: migrationCheckInterval}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
 // Handle NetworkAddonsConfig object. Canonicalize, validate and finally render objects for all
-// desired components. Please note that this function has side effects, it reads config map
-// containing previously saved NetworkAddonsConfig and OpenShift's Network operator config.
-func (r *ReconcileNetworkAddonsConfig) renderObjects(networkAddonsConfig *opv1alpha1.NetworkAddonsConfig) ([]*unstructured.Unstructured, error) {
+// desired components. Objects of components which were removed from the configuration since it
+// was applied the last time and previously applied objects which are not rendered anymore are
+// returned too, so they can be deleted. Please note that this
+// function has side effects, it reads config map containing previously saved
+// NetworkAddonsConfig and OpenShift's Network operator config.
+func (r *ReconcileNetworkAddonsConfig) renderObjects(networkAddonsConfig *opv1.NetworkAddonsConfig) ([]*unstructured.Unstructured, []*unstructured.Unstructured, error) {
 	objs := []*unstructured.Unstructured{}
 
+	// Canonicalize, validate and fill defaults of the configuration and make sure that it can be
+	// safely applied over the previous one
+	openshiftNetworkConfig, prev, err := validateConfig(context.TODO(), r.client, networkAddonsConfig, r.namespace, r.clusterInfo)
+	if err != nil {
+		if unsafeChange, isUnsafeChange := errors.Cause(err).(unsafeChangeError); isUnsafeChange {
+			r.recorder.Eventf(networkAddonsConfig, corev1.EventTypeWarning, "UnsafeChangeRejected", "Change of the configuration was not applied: %v", unsafeChange)
+		}
+		return objs, nil, invalidConfigError{err}
+	}
+
+	// Migrate objects deployed by older versions of the operator
+	migrations, err := r.migrate(networkAddonsConfig, prev)
+	if err != nil {
+		log.Printf("failed to migrate objects deployed by older versions: %v", err)
+		err = errors.Wrapf(err, "failed to migrate objects deployed by older versions")
+		return objs, nil, err
+	}
+
+	// Generate the objects
+	components, err := network.RenderComponents(&networkAddonsConfig.Spec, ManifestPath, openshiftNetworkConfig, r.clusterInfo)
+	if err != nil {
+		log.Printf("failed to render: %v", err)
+		err = errors.Wrapf(err, "failed to render")
+		return objs, nil, err
+	}
+	for _, component := range components {
+		objs = append(objs, component.Objects...)
+	}
+
+	// Customize rendered objects by patches of the configuration
+	objs, err = network.ApplyPatches(&networkAddonsConfig.Spec, objs)
+	if err != nil {
+		log.Printf("failed to apply patches: %v", err)
+		err = errors.Wrapf(err, "failed to apply patches")
+		return objs, nil, err
+	}
+
+	// Find objects of removed components under the names they were applied with
+	inventory, err := getAppliedInventory(context.TODO(), r.client, networkAddonsConfig.ObjectMeta.Name, r.namespace)
+	if err != nil {
+		log.Printf("failed to retrieve inventory of applied objects: %v", err)
+		err = errors.Wrapf(err, "failed to retrieve inventory of applied objects")
+		return objs, nil, err
+	}
+	removedObjs, err := network.RenderRemoved(prev, &networkAddonsConfig.Spec, inventory, objs, ManifestPath, openshiftNetworkConfig, r.clusterInfo)
+	if err != nil {
+		log.Printf("failed to render removed components: %v", err)
+		err = errors.Wrapf(err, "failed to render removed components")
+		return objs, nil, err
+	}
+	if removed := network.RemovedComponents(prev, &networkAddonsConfig.Spec); len(removed) > 0 {
+		log.Printf("components %v were removed from the configuration", removed)
+	}
+
+	// Objects which were applied previously, but are not rendered anymore, have to be pruned too
+	removedObjs = withPrunePolicy(removedObjs, apply.Obsolete(inventory, objs))
+
+	// The first object we create should be the record of our applied configuration
+	applied, err := appliedConfiguration(networkAddonsConfig, network.Inventory(components, objs), migrations, r.namespace)
+	if err != nil {
+		log.Printf("failed to render applied: %v", err)
+		err = errors.Wrapf(err, "failed to render applied")
+		return objs, nil, err
+	}
+	objs = append([]*unstructured.Unstructured{applied}, objs...)
+
+	// Label objects with version of the operator they were created by, and as owned by the
+	// operator, so they are watched for modifications
+	for _, obj := range objs {
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[opv1.SchemeGroupVersion.Group+"/version"] = operatorVersion
+		labels[network.OwnedLabel] = "true"
+		obj.SetLabels(labels)
+	}
+
+	return objs, removedObjs, nil
+}
+
+// withPrunePolicy merges objects of removed components with obsolete objects which are not rendered
+// anymore, keeping only those which may be deleted:
+//   - The namespace of the operator is never deleted, the operator itself runs there.
+//   - CRDs are deleted only together with their component, when it is removed from the config.
+//     A CRD which was dropped from templates is orphaned, since its custom resources may be
+//     owned by users.
+//   - Everything else is deleted.
+func withPrunePolicy(removedObjs, obsoleteObjs []*unstructured.Unstructured) []*unstructured.Unstructured {
+	isOperatorNamespace := func(obj *unstructured.Unstructured) bool {
+		return obj.GetKind() == "Namespace" && obj.GetName() == operatorNamespace
+	}
+	isCRD := func(obj *unstructured.Unstructured) bool {
+		return obj.GetKind() == "CustomResourceDefinition"
+	}
+
+	pruned := []*unstructured.Unstructured{}
+	seen := map[string]bool{}
+	for _, obj := range removedObjs {
+		seen[apply.ObjectKey(obj)] = true
+		if isOperatorNamespace(obj) {
+			continue
+		}
+		pruned = append(pruned, obj)
+	}
+	for _, obj := range obsoleteObjs {
+		if seen[apply.ObjectKey(obj)] || isOperatorNamespace(obj) {
+			continue
+		}
+		if isCRD(obj) {
+			log.Printf("orphaning obsolete (%s) %s, custom resources may be left behind", obj.GroupVersionKind(), obj.GetName())
+			continue
+		}
+		pruned = append(pruned, obj)
+	}
+	return pruned
+}
+
+// migrate runs migrations which were not run on the cluster yet and returns IDs of all finished
+// migrations. When nothing was deployed before, there is nothing to migrate and all migrations are
+// considered finished.
+func (r *ReconcileNetworkAddonsConfig) migrate(networkAddonsConfig *opv1.NetworkAddonsConfig, prev *opv1.NetworkAddonsConfigSpec) ([]string, error) {
+	migrations := network.Migrations()
+	if prev == nil {
+		return migration.IDs(migrations), nil
+	}
+
+	finished, err := getAppliedMigrations(context.TODO(), r.client, networkAddonsConfig.ObjectMeta.Name, r.namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve finished migrations")
+	}
+
+	pending, err := migration.Pending(migrations, finished)
+	if err != nil {
+		return nil, err
+	}
+
+	if networkAddonsConfig.Spec.DryRun {
+		if len(pending) > 0 {
+			log.Printf("dry run, not running migrations %v", migration.IDs(pending))
+		}
+		return finished, nil
+	}
+
+	// Migrations finished now are not recorded when a later one fails, they will be run again
+	// on the next attempt. That is fine, since they are idempotent.
+	done, err := migration.Run(context.TODO(), r.client, pending)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(finished, done...), nil
+}
+
+// migrationsWaiting checks whether some migrations were not finished yet, i.e. they are waiting
+// for replacements of obsolete objects to be rolled out
+func (r *ReconcileNetworkAddonsConfig) migrationsWaiting(networkAddonsConfig *opv1.NetworkAddonsConfig) (bool, error) {
+	finished, err := getAppliedMigrations(context.TODO(), r.client, networkAddonsConfig.ObjectMeta.Name, r.namespace)
+	if err != nil {
+		return false, err
+	}
+
+	pending, err := migration.Pending(network.Migrations(), finished)
+	if err != nil {
+		return false, err
+	}
+	return len(pending) > 0, nil
+}
+
+// defaultConfig converts NetworkAddonsConfig to a canonical form, validates it and fills in its
+// defaults. The given NetworkAddonsConfig is modified in place. It returns the previously applied
+// configuration, so it can be used to check whether the change is safe.
+func defaultConfig(ctx context.Context, c k8sclient.Client, networkAddonsConfig *opv1.NetworkAddonsConfig, namespace string, clusterInfo *network.ClusterInfo) (*osv1.Network, *opv1.NetworkAddonsConfigSpec, error) {
 	// Convert to a canonicalized form
 	network.Canonicalize(&networkAddonsConfig.Spec)
 
 	// Read OpenShift network operator configuration (if exists)
-	openshiftNetworkConfig, err := getOpenShiftNetworkConfig(context.TODO(), r.client)
+	openshiftNetworkConfig, err := getOpenShiftNetworkConfig(ctx, c)
 	if err != nil {
 		log.Printf("failed to load OpenShift NetworkConfig: %v", err)
 		err = errors.Wrapf(err, "failed to load OpenShift NetworkConfig: %v", err)
-		return objs, err
+		return nil, nil, err
 	}
 
 	// Validate the configuration
-	if err := network.Validate(&networkAddonsConfig.Spec, openshiftNetworkConfig); err != nil {
+	if err := network.Validate(&networkAddonsConfig.Spec, openshiftNetworkConfig, clusterInfo); err != nil {
 		log.Printf("failed to validate NetworkConfig.Spec: %v", err)
 		err = errors.Wrapf(err, "failed to validate NetworkConfig.Spec: %v", err)
-		return objs, err
+		return nil, nil, err
 	}
 
 	// Retrieve the previously applied operator configuration
-	prev, err := getAppliedConfiguration(context.TODO(), r.client, networkAddonsConfig.ObjectMeta.Name, r.namespace)
+	prev, err := getAppliedConfiguration(ctx, c, networkAddonsConfig.ObjectMeta.Name, namespace)
 	if err != nil {
 		log.Printf("failed to retrieve previously applied configuration: %v", err)
 		err = errors.Wrapf(err, "failed to retrieve previously applied configuration: %v", err)
-		return objs, err
+		return nil, nil, err
 	}
 
 	// Fill all defaults explicitly
 	if err := network.FillDefaults(&networkAddonsConfig.Spec, prev); err != nil {
 		log.Printf("failed to fill defaults: %v", err)
 		err = errors.Wrapf(err, "failed to fill defaults: %v", err)
-		return objs, err
+		return nil, nil, err
+	}
+
+	return openshiftNetworkConfig, prev, nil
+}
+
+// validateConfig fills defaults of NetworkAddonsConfig and checks whether it can be safely applied
+// over the previously applied configuration. The given NetworkAddonsConfig is modified in place.
+// This is shared by the reconcile loop and the validating webhook, so both of them report the
+// very same errors. The previously applied configuration is returned too, if there is any.
+func validateConfig(ctx context.Context, c k8sclient.Client, networkAddonsConfig *opv1.NetworkAddonsConfig, namespace string, clusterInfo *network.ClusterInfo) (*osv1.Network, *opv1.NetworkAddonsConfigSpec, error) {
+	openshiftNetworkConfig, prev, err := defaultConfig(ctx, c, networkAddonsConfig, namespace, clusterInfo)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Compare against previous applied configuration to see if this change
@@ -273,66 +666,133 @@ func (r *ReconcileNetworkAddonsConfig) renderObjects(networkAddonsConfig *opv1al
 		err = network.IsChangeSafe(prev, &networkAddonsConfig.Spec)
 		if err != nil {
 			log.Printf("not applying unsafe change: %v", err)
-			err = errors.Wrapf(err, "not applying unsafe change")
-			return objs, err
+			err = errors.Wrapf(unsafeChangeError{err}, "not applying unsafe change")
+			return nil, nil, err
 		}
 	}
 
-	// Clean Up any outdated obsoleted objects
-	if err := network.SpecialCleanUp(networkAddonsConfig, r.client, objs); err != nil {
-		log.Printf("failed to Clean Up outdated objects: %v", err)
-		return objs, err
-	}
+	return openshiftNetworkConfig, prev, nil
+}
 
-	// Generate the objects
-	objs, err = network.Render(&networkAddonsConfig.Spec, ManifestPath, openshiftNetworkConfig, r.clusterInfo)
+// invalidConfigError marks a failure of validation of the configuration, as opposed to failures
+// of its rendering
+type invalidConfigError struct {
+	error
+}
+
+// unsafeChangeError marks a change of the configuration which is not supported by deployed
+// components, so it can be reported separately from other failures
+type unsafeChangeError struct {
+	error
+}
+
+// Apply the objects to the cluster in stages ordered by their kind, see applyStages. Set their
+// controller reference to NetworkAddonsConfig, so they are removed when NetworkAddonsConfig config
+// is. Once a stage is applied, it is checked whether its objects are ready. If they are not, or
+// some of them are still being deleted to be recreated, the following stages are not applied and
+// the name of the pending stage is returned. The number of
+// objects which were created, updated or recreated is returned too.
+func (r *ReconcileNetworkAddonsConfig) applyObjects(networkAddonsConfig *opv1.NetworkAddonsConfig, objs []*unstructured.Unstructured) (int, string, error) {
+	changed := 0
+
+	missing, err := r.missingObjects(networkAddonsConfig)
 	if err != nil {
-		log.Printf("failed to render: %v", err)
-		err = errors.Wrapf(err, "failed to render")
-		return objs, err
+		log.Printf("failed to look up missing objects: %v", err)
+		return changed, "", errors.Wrap(err, "failed to look up missing objects")
 	}
 
-	// The first object we create should be the record of our applied configuration
-	applied, err := appliedConfiguration(networkAddonsConfig, r.namespace)
-	if err != nil {
-		log.Printf("failed to render applied: %v", err)
-		err = errors.Wrapf(err, "failed to render applied")
-		return objs, err
+	for _, stage := range r.applyStages(networkAddonsConfig, objs) {
+		recreating := false
+		for _, obj := range stage.Objects {
+			if err := r.setControllerReference(networkAddonsConfig, obj); err != nil {
+				return changed, "", err
+			}
+
+			// Apply all objects on apiserver
+			objChanged, err := apply.ApplyObject(context.TODO(), r.client, r.recorder, obj)
+			if objChanged {
+				changed++
+			}
+			if apply.IsRecreatePending(err) {
+				log.Printf("waiting for (%s) %s/%s to be deleted before it is recreated", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+				recreating = true
+				continue
+			}
+			if err != nil {
+				log.Printf("could not apply (%s) %s/%s: %v", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), err)
+				err = errors.Wrapf(err, "could not apply (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+				return changed, "", err
+			}
+
+			if missing[apply.ObjectKey(obj)] {
+				r.reportRestored(obj)
+			}
+		}
+
+		if recreating {
+			return changed, stage.Name, nil
+		}
+
+		// Objects of following stages may depend on this one, e.g. custom resources on their CRD
+		notReady, err := apply.NotReady(context.TODO(), r.client, stage.Objects)
+		if err != nil {
+			log.Printf("failed to check whether %s are ready: %v", stage.Name, err)
+			return changed, "", errors.Wrapf(err, "failed to check whether %s are ready", stage.Name)
+		}
+		if notReady != nil {
+			log.Printf("waiting for (%s) %s/%s to become ready", notReady.GroupVersionKind(), notReady.GetNamespace(), notReady.GetName())
+			return changed, stage.Name, nil
+		}
 	}
-	objs = append([]*unstructured.Unstructured{applied}, objs...)
 
-	// Label objects with version of the operator they were created by
+	return changed, "", nil
+}
+
+// applyStages sorts objects into stages in which they are applied, see network.ApplyStages. The
+// record of the applied configuration goes first, before any of the stages. It lives in the
+// namespace of the operator, so it does not depend on anything rendered.
+func (r *ReconcileNetworkAddonsConfig) applyStages(networkAddonsConfig *opv1.NetworkAddonsConfig, objs []*unstructured.Unstructured) []network.ApplyStage {
+	recordKey := apply.ObjectKey(appliedConfigurationObject(networkAddonsConfig.Name, r.namespace))
+
+	record := []*unstructured.Unstructured{}
+	rendered := []*unstructured.Unstructured{}
 	for _, obj := range objs {
-		labels := obj.GetLabels()
-		if labels == nil {
-			labels = map[string]string{}
+		if apply.ObjectKey(obj) == recordKey {
+			record = append(record, obj)
+		} else {
+			rendered = append(rendered, obj)
 		}
-		labels[opv1alpha1.SchemeGroupVersion.Group+"/version"] = operatorVersion
-		obj.SetLabels(labels)
 	}
 
-	return objs, nil
+	return append([]network.ApplyStage{{Name: "Applied configuration", Objects: record}}, network.ApplyStages(rendered)...)
 }
 
-// Apply the objects to the cluster. Set their controller reference to NetworkAddonsConfig, so they
-// are removed when NetworkAddonsConfig config is
-func (r *ReconcileNetworkAddonsConfig) applyObjects(networkAddonsConfig *opv1alpha1.NetworkAddonsConfig, objs []*unstructured.Unstructured) error {
+// Mark the object to be GC'd if the owner is deleted. Don't set owner reference on namespaces if they are used by the operator itself
+func (r *ReconcileNetworkAddonsConfig) setControllerReference(networkAddonsConfig *opv1.NetworkAddonsConfig, obj *unstructured.Unstructured) error {
+	if obj.GetKind() == "Namespace" && obj.GetName() == operatorNamespace {
+		return nil
+	}
+	if err := controllerutil.SetControllerReference(networkAddonsConfig, obj, r.scheme); err != nil {
+		log.Printf("could not set reference for (%s) %s/%s: %v", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), err)
+		return errors.Wrapf(err, "could not set reference for (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+	}
+	return nil
+}
+
+// Delete objects of components which were removed from the configuration. Their dependants, such
+// as pods of DaemonSets, are garbage collected in background
+func (r *ReconcileNetworkAddonsConfig) removeObjects(objs []*unstructured.Unstructured) error {
 	for _, obj := range objs {
-		// Mark the object to be GC'd if the owner is deleted. Don't set owner reference on namespaces if they are used by the operator itself
-		if !(obj.GetKind() == "Namespace" && obj.GetName() == operatorNamespace) {
-			if err := controllerutil.SetControllerReference(networkAddonsConfig, obj, r.scheme); err != nil {
-				log.Printf("could not set reference for (%s) %s/%s: %v", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), err)
-				err = errors.Wrapf(err, "could not set reference for (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
-				return err
-			}
+		err := r.client.Delete(context.TODO(), obj, k8sclient.PropagationPolicy(metav1.DeletePropagationBackground))
+		if apierrors.IsNotFound(err) {
+			continue
 		}
-
-		// Apply all objects on apiserver
-		if err := apply.ApplyObject(context.TODO(), r.client, obj); err != nil {
-			log.Printf("could not apply (%s) %s/%s: %v", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), err)
-			err = errors.Wrapf(err, "could not apply (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+		if err != nil {
+			log.Printf("could not remove (%s) %s/%s: %v", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), err)
+			err = errors.Wrapf(err, "could not remove (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 			return err
 		}
+		log.Printf("removed (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 	}
 
 	return nil
@@ -340,15 +800,19 @@ func (r *ReconcileNetworkAddonsConfig) applyObjects(networkAddonsConfig *opv1alp
 
 // Track current state of Deployments and DaemonSets deployed by the operator. This is needed to
 // keep state of NetworkAddonsConfig up-to-date, e.g. mark as Ready once all objects are successfully
-// created. This also exposes all containers and their images used by deployed components in Status.
+// created. State of the workloads is reported per component they belong to. This also exposes all
+// containers and their images used by deployed components in Status.
 func (r *ReconcileNetworkAddonsConfig) trackDeployedObjects(objs []*unstructured.Unstructured) {
 	daemonSets := []types.NamespacedName{}
 	deployments := []types.NamespacedName{}
-	containers := []opv1alpha1.Container{}
+	workloads := []statusmanager.Workload{}
+	containers := []opv1.Container{}
 
 	for _, obj := range objs {
 		if obj.GetAPIVersion() == "apps/v1" && obj.GetKind() == "DaemonSet" {
-			daemonSets = append(daemonSets, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()})
+			name := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+			daemonSets = append(daemonSets, name)
+			workloads = append(workloads, statusmanager.Workload{Component: network.ComponentOf(obj), Kind: obj.GetKind(), Name: name})
 
 			daemonSet, err := unstructuredToDaemonSet(obj)
 			if err != nil {
@@ -357,7 +821,7 @@ func (r *ReconcileNetworkAddonsConfig) trackDeployedObjects(objs []*unstructured
 			}
 
 			for _, container := range daemonSet.Spec.Template.Spec.Containers {
-				containers = append(containers, opv1alpha1.Container{
+				containers = append(containers, opv1.Container{
 					ParentKind: obj.GetKind(),
 					ParentName: daemonSet.GetName(),
 					Image:      container.Image,
@@ -365,7 +829,9 @@ func (r *ReconcileNetworkAddonsConfig) trackDeployedObjects(objs []*unstructured
 				})
 			}
 		} else if obj.GetAPIVersion() == "apps/v1" && obj.GetKind() == "Deployment" {
-			deployments = append(deployments, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()})
+			name := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+			deployments = append(deployments, name)
+			workloads = append(workloads, statusmanager.Workload{Component: network.ComponentOf(obj), Kind: obj.GetKind(), Name: name})
 
 			deployment, err := unstructuredToDeployment(obj)
 			if err != nil {
@@ -374,7 +840,7 @@ func (r *ReconcileNetworkAddonsConfig) trackDeployedObjects(objs []*unstructured
 			}
 
 			for _, container := range deployment.Spec.Template.Spec.Containers {
-				containers = append(containers, opv1alpha1.Container{
+				containers = append(containers, opv1.Container{
 					ParentKind: obj.GetKind(),
 					ParentName: deployment.GetName(),
 					Image:      container.Image,
@@ -384,8 +850,7 @@ func (r *ReconcileNetworkAddonsConfig) trackDeployedObjects(objs []*unstructured
 		}
 	}
 
-	r.statusManager.SetDaemonSets(daemonSets)
-	r.statusManager.SetDeployments(deployments)
+	r.statusManager.SetWorkloads(workloads)
 	r.statusManager.SetContainers(containers)
 
 	allResources := []types.NamespacedName{}
@@ -424,6 +889,18 @@ func isSCCAvailable(c kubernetes.Interface) (bool, error) {
 	return isResourceAvailable(c, "securitycontextconstraints", "security.openshift.io", "v1")
 }
 
+// isMonitoringAvailable checks whether CRDs of Prometheus operator are installed, so ServiceMonitor
+// and PrometheusRule can be deployed
+func isMonitoringAvailable(c kubernetes.Interface) (bool, error) {
+	for _, resource := range []string{"servicemonitors", "prometheusrules"} {
+		available, err := isResourceAvailable(c, resource, "monitoring.coreos.com", "v1")
+		if err != nil || !available {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 func isResourceAvailable(kubeClient kubernetes.Interface, name string, group string, version string) (bool, error) {
 	result := kubeClient.ExtensionsV1beta1().RESTClient().Get().RequestURI("/apis/" + group + "/" + version + "/" + name).Do()
 	if result.Error() != nil {
@@ -436,7 +913,7 @@ func isResourceAvailable(kubeClient kubernetes.Interface, name string, group str
 	return true, nil
 }
 
-func runtimeObjectToNetworkAddonsConfig(obj runtime.Object) (*opv1alpha1.NetworkAddonsConfig, error) {
+func runtimeObjectToNetworkAddonsConfig(obj runtime.Object) (*opv1.NetworkAddonsConfig, error) {
 	// convert the runtime.Object to unstructured.Unstructured
 	unstructuredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
 	if err != nil {
@@ -444,7 +921,7 @@ func runtimeObjectToNetworkAddonsConfig(obj runtime.Object) (*opv1alpha1.Network
 	}
 
 	// convert unstructured.Unstructured to a NetworkAddonsConfig
-	networkAddonsConfig := &opv1alpha1.NetworkAddonsConfig{}
+	networkAddonsConfig := &opv1.NetworkAddonsConfig{}
 	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredObj, networkAddonsConfig); err != nil {
 		return nil, err
 	}
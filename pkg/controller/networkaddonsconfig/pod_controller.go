@@ -2,12 +2,21 @@ package networkaddonsconfig
 
 import (
 	"log"
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/kubevirt/cluster-network-addons-operator/pkg/controller/statusmanager"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/network"
 )
 
 var resyncPeriod = 5 * time.Minute
@@ -38,8 +47,59 @@ func (r *ReconcilePods) Reconcile(request reconcile.Request) (reconcile.Result,
 		if name.Namespace == request.Namespace && name.Name == request.Name {
 			log.Printf("Reconciling update to %s/%s\n", request.Namespace, request.Name)
 			r.statusManager.SetFromPods()
-			return reconcile.Result{RequeueAfter: resyncPeriod}, nil
+
+			// Check the status again once a rollout in progress reaches its deadline
+			requeueAfter := resyncPeriod
+			if untilDeadline, found := r.statusManager.UntilProgressDeadline(); found && untilDeadline < requeueAfter {
+				requeueAfter = untilDeadline
+			}
+			return reconcile.Result{RequeueAfter: requeueAfter}, nil
 		}
 	}
 	return reconcile.Result{}, nil
 }
+
+// newComponentPodInformer returns an informer of pods labeled by network.ComponentLabel, i.e. pods
+// of DaemonSets and Deployments deployed by the operator, in all namespaces
+func newComponentPodInformer(clientset kubernetes.Interface) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				options.LabelSelector = network.ComponentLabel
+				return clientset.CoreV1().Pods(metav1.NamespaceAll).List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				options.LabelSelector = network.ComponentLabel
+				return clientset.CoreV1().Pods(metav1.NamespaceAll).Watch(options)
+			},
+		},
+		&corev1.Pod{},
+		0,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+	)
+}
+
+// podWorkloadRequests maps a pod to the DaemonSet or Deployment which created it. Pods of Deployments
+// are owned by ReplicaSets, which are named after the Deployment suffixed by hash of the pod template
+func podWorkloadRequests(obj handler.MapObject) []reconcile.Request {
+	owner := metav1.GetControllerOf(obj.Meta)
+	if owner == nil {
+		return nil
+	}
+
+	name := ""
+	switch owner.Kind {
+	case "DaemonSet":
+		name = owner.Name
+	case "ReplicaSet":
+		hash := obj.Meta.GetLabels()["pod-template-hash"]
+		if hash == "" || !strings.HasSuffix(owner.Name, "-"+hash) {
+			return nil
+		}
+		name = strings.TrimSuffix(owner.Name, "-"+hash)
+	default:
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.Meta.GetNamespace(), Name: name}}}
+}
@@ -11,14 +11,15 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
-	opv1alphav1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
 	"github.com/kubevirt/cluster-network-addons-operator/pkg/names"
 	k8sutil "github.com/kubevirt/cluster-network-addons-operator/pkg/util/k8s"
 )
 
 // GetAppliedConfiguration retrieves the configuration we applied.
 // Returns nil with no error if no previous configuration was observed.
-func getAppliedConfiguration(ctx context.Context, client k8sclient.Client, name string, namespace string) (*opv1alphav1.NetworkAddonsConfigSpec, error) {
+func getAppliedConfiguration(ctx context.Context, client k8sclient.Client, name string, namespace string) (*opv1.NetworkAddonsConfigSpec, error) {
 	cm := &corev1.ConfigMap{}
 	err := client.Get(ctx, types.NamespacedName{Name: names.APPLIED_PREFIX + name, Namespace: namespace}, cm)
 	if err != nil && apierrors.IsNotFound(err) {
@@ -27,7 +28,7 @@ func getAppliedConfiguration(ctx context.Context, client k8sclient.Client, name
 		return nil, err
 	}
 
-	spec := &opv1alphav1.NetworkAddonsConfigSpec{}
+	spec := &opv1.NetworkAddonsConfigSpec{}
 	err = json.Unmarshal([]byte(cm.Data["applied"]), spec)
 	if err != nil {
 		return nil, err
@@ -35,13 +36,66 @@ func getAppliedConfiguration(ctx context.Context, client k8sclient.Client, name
 	return spec, nil
 }
 
+// getAppliedInventory retrieves the list of objects we applied together with the configuration.
+// Returns nil with no error if no inventory was stored, e.g. by an older version of the operator.
+func getAppliedInventory(ctx context.Context, client k8sclient.Client, name string, namespace string) ([]apply.InventoryItem, error) {
+	cm := &corev1.ConfigMap{}
+	err := client.Get(ctx, types.NamespacedName{Name: names.APPLIED_PREFIX + name, Namespace: namespace}, cm)
+	if err != nil && apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if cm.Data["inventory"] == "" {
+		return nil, nil
+	}
+
+	inventory := []apply.InventoryItem{}
+	err = json.Unmarshal([]byte(cm.Data["inventory"]), &inventory)
+	if err != nil {
+		return nil, err
+	}
+	return inventory, nil
+}
+
+// getAppliedMigrations retrieves IDs of migrations which were already run on the cluster.
+func getAppliedMigrations(ctx context.Context, client k8sclient.Client, name string, namespace string) ([]string, error) {
+	cm := &corev1.ConfigMap{}
+	err := client.Get(ctx, types.NamespacedName{Name: names.APPLIED_PREFIX + name, Namespace: namespace}, cm)
+	if err != nil && apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if cm.Data["migrations"] == "" {
+		return nil, nil
+	}
+
+	migrations := []string{}
+	err = json.Unmarshal([]byte(cm.Data["migrations"]), &migrations)
+	if err != nil {
+		return nil, err
+	}
+	return migrations, nil
+}
+
 // AppliedConfiguration renders the ConfigMap in which we store the configuration
-// we've applied.
-func appliedConfiguration(applied *opv1alphav1.NetworkAddonsConfig, namespace string) (*uns.Unstructured, error) {
+// we've applied, together with the inventory of applied objects and finished migrations.
+func appliedConfiguration(applied *opv1.NetworkAddonsConfig, inventory []apply.InventoryItem, migrations []string, namespace string) (*uns.Unstructured, error) {
 	app, err := json.Marshal(applied.Spec)
 	if err != nil {
 		return nil, err
 	}
+	inv, err := json.Marshal(inventory)
+	if err != nil {
+		return nil, err
+	}
+	mig, err := json.Marshal(migrations)
+	if err != nil {
+		return nil, err
+	}
 	cm := &corev1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
@@ -52,7 +106,9 @@ func appliedConfiguration(applied *opv1alphav1.NetworkAddonsConfig, namespace st
 			Namespace: namespace,
 		},
 		Data: map[string]string{
-			"applied": string(app),
+			"applied":    string(app),
+			"inventory":  string(inv),
+			"migrations": string(mig),
 		},
 	}
 
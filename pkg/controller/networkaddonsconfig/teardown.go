@@ -0,0 +1,209 @@
+package networkaddonsconfig
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/pkg/errors"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/controller/statusmanager"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/names"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/network"
+)
+
+// teardownFinalizer keeps NetworkAddonsConfig around until all deployed components are removed
+const teardownFinalizer = "networkaddonsoperator.network.kubevirt.io/teardown"
+
+// ensureFinalizer makes sure that the config will not disappear before its components are removed
+func (r *ReconcileNetworkAddonsConfig) ensureFinalizer(networkAddonsConfig *opv1.NetworkAddonsConfig) error {
+	if containsString(networkAddonsConfig.GetFinalizers(), teardownFinalizer) {
+		return nil
+	}
+
+	log.Print("adding teardown finalizer to NetworkAddonsConfig")
+	networkAddonsConfig.SetFinalizers(append(networkAddonsConfig.GetFinalizers(), teardownFinalizer))
+	if err := r.client.Update(context.TODO(), networkAddonsConfig); err != nil {
+		log.Printf("failed to add teardown finalizer to NetworkAddonsConfig: %v", err)
+		return errors.Wrap(err, "failed to add teardown finalizer to NetworkAddonsConfig")
+	}
+	return nil
+}
+
+// reconcileDeletion tears down all deployed components of a deleted config stage by stage. Only
+// once everything is gone, the finalizer is removed and the config is released
+func (r *ReconcileNetworkAddonsConfig) reconcileDeletion(networkAddonsConfig *opv1.NetworkAddonsConfig) (reconcile.Result, error) {
+	if !containsString(networkAddonsConfig.GetFinalizers(), teardownFinalizer) {
+		return reconcile.Result{}, nil
+	}
+
+	// Deployed objects are going away, they must not be reported as failing
+	r.trackDeployedObjects([]*unstructured.Unstructured{})
+
+	done, err := r.teardown(networkAddonsConfig)
+	if err != nil {
+		r.statusManager.SetFailing(statusmanager.OperatorConfig, "FailedToTearDown", err.Error())
+		return reconcile.Result{}, err
+	}
+	r.statusManager.SetNotFailing(statusmanager.OperatorConfig)
+	if !done {
+		return reconcile.Result{RequeueAfter: removalCheckInterval}, nil
+	}
+
+	log.Print("all components were torn down, removing finalizer from NetworkAddonsConfig")
+	networkAddonsConfig.SetFinalizers(withoutString(networkAddonsConfig.GetFinalizers(), teardownFinalizer))
+	if err := r.client.Update(context.TODO(), networkAddonsConfig); err != nil {
+		log.Printf("failed to remove teardown finalizer from NetworkAddonsConfig: %v", err)
+		err = errors.Wrap(err, "failed to remove teardown finalizer from NetworkAddonsConfig")
+		r.statusManager.SetFailing(statusmanager.OperatorConfig, "FailedToTearDown", err.Error())
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// teardown removes objects of the first stage which has some of them left. It reports whether
+// all stages are done
+func (r *ReconcileNetworkAddonsConfig) teardown(networkAddonsConfig *opv1.NetworkAddonsConfig) (bool, error) {
+	objs, err := r.deployedObjects(networkAddonsConfig)
+	if err != nil {
+		return false, err
+	}
+
+	for _, stage := range network.TeardownStages(objs) {
+		remaining := []string{}
+		for _, obj := range stage.Objects {
+			gone, err := r.removeForTeardown(obj)
+			if err != nil {
+				return false, err
+			}
+			if !gone {
+				remaining = append(remaining, fmt.Sprintf("%s %q", obj.GetKind(), types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}.String()))
+			}
+		}
+
+		if len(remaining) > 0 {
+			log.Printf("tearing down %s, waiting for %d objects to be removed", stage.Name, len(remaining))
+			r.statusManager.SetTearingDown(fmt.Sprintf("Removing %s:\n%s", stage.Name, strings.Join(remaining, "\n")))
+			return false, nil
+		}
+	}
+
+	// Finally forget about the applied configuration
+	gone, err := r.removeForTeardown(appliedConfigurationObject(networkAddonsConfig.Name, r.namespace))
+	if err != nil || !gone {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// deployedObjects lists all objects which were deployed for the config. That covers objects recorded
+// in the inventory and objects created by components on runtime. The operator namespace is never
+// removed. Objects of a config applied by a version of the operator which did not keep the
+// inventory are left to the garbage collector, they are all owned by the config.
+func (r *ReconcileNetworkAddonsConfig) deployedObjects(networkAddonsConfig *opv1.NetworkAddonsConfig) ([]*unstructured.Unstructured, error) {
+	ctx := context.TODO()
+
+	inventory, err := getAppliedInventory(ctx, r.client, networkAddonsConfig.Name, r.namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve inventory of applied objects")
+	}
+	candidates := []*unstructured.Unstructured{}
+	for _, item := range inventory {
+		candidates = append(candidates, item.Object())
+	}
+
+	runtimeObjs, err := r.runtimeObjects(ctx, candidates)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to look up objects created by components")
+	}
+	candidates = append(candidates, runtimeObjs...)
+
+	objs := []*unstructured.Unstructured{}
+	seen := map[string]bool{}
+	for _, obj := range candidates {
+		if seen[apply.ObjectKey(obj)] || (obj.GetKind() == "Namespace" && obj.GetName() == operatorNamespace) {
+			continue
+		}
+		seen[apply.ObjectKey(obj)] = true
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+// runtimeObjects looks up objects created on runtime by deployed components, see network.RuntimeObjects
+func (r *ReconcileNetworkAddonsConfig) runtimeObjects(ctx context.Context, deployed []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	mutating := &admissionregistrationv1beta1.MutatingWebhookConfigurationList{}
+	if err := r.client.List(ctx, &k8sclient.ListOptions{}, mutating); err != nil {
+		return nil, errors.Wrap(err, "failed to list mutating webhook configurations")
+	}
+
+	return network.RuntimeObjects(deployed, mutating.Items), nil
+}
+
+// removeForTeardown deletes the object, including its dependants, and reports whether it is gone
+// already. Objects of kinds unknown to the cluster, such as SCCs outside of OpenShift, are gone too
+func (r *ReconcileNetworkAddonsConfig) removeForTeardown(obj *unstructured.Unstructured) (bool, error) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, existing)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "could not retrieve (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+	}
+
+	// Deletion was already requested, wait for it to finish
+	if existing.GetDeletionTimestamp() != nil {
+		return false, nil
+	}
+
+	err = r.client.Delete(context.TODO(), existing, k8sclient.PropagationPolicy(metav1.DeletePropagationForeground))
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		log.Printf("could not remove (%s) %s/%s: %v", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), err)
+		return false, errors.Wrapf(err, "could not remove (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+	}
+	log.Printf("removing (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+
+	return false, nil
+}
+
+// appliedConfigurationObject identifies the ConfigMap holding the applied configuration
+func appliedConfigurationObject(name, namespace string) *unstructured.Unstructured {
+	return apply.InventoryItem{Version: "v1", Kind: "ConfigMap", Namespace: namespace, Name: names.APPLIED_PREFIX + name}.Object()
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutString(list []string, s string) []string {
+	filtered := []string{}
+	for _, item := range list {
+		if item != s {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
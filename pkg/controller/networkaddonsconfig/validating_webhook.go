@@ -0,0 +1,117 @@
+package networkaddonsconfig
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	atypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/types"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	opv1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/names"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/network"
+)
+
+// newValidatingWebhook returns a webhook rejecting NetworkAddonsConfigs that would fail
+// validation or change-safety checks during reconciliation
+func newValidatingWebhook(client client.Client, scheme *runtime.Scheme, namespace string, clusterInfo *network.ClusterInfo) *admission.Webhook {
+	// If the operator is not running, we don't want to block all changes of the config.
+	// Reconcile loop performs the same checks, so invalid configurations are still reported
+	// in the Degraded condition.
+	failurePolicy := admissionregistrationv1beta1.Ignore
+
+	return &admission.Webhook{
+		Name:          "validate.networkaddonsconfigs." + opv1.SchemeGroupVersion.Group,
+		Type:          types.WebhookTypeValidating,
+		Rules:         configRules(),
+		FailurePolicy: &failurePolicy,
+		Handlers: []admission.Handler{
+			&configValidator{client: client, codecs: serializer.NewCodecFactory(scheme), namespace: namespace, clusterInfo: clusterInfo},
+		},
+	}
+}
+
+// configValidator runs the same checks on NetworkAddonsConfig as the reconcile loop does
+// before rendering, so invalid or unsafe changes are rejected right away by the API server
+type configValidator struct {
+	client      client.Client
+	codecs      serializer.CodecFactory
+	namespace   string
+	clusterInfo *network.ClusterInfo
+}
+
+var _ admission.Handler = &configValidator{}
+
+func (v *configValidator) Handle(ctx context.Context, req atypes.Request) atypes.Response {
+	networkAddonsConfig, err := decodeConfig(v.codecs, req.AdmissionRequest.Object.Raw)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+
+	// Configs with other than the default name are ignored by the operator
+	if networkAddonsConfig.Name != names.OPERATOR_CONFIG {
+		return admission.ValidationResponse(true, "")
+	}
+
+	// Don't block updates which do not touch the Spec, e.g. changes of labels or finalizers
+	if req.AdmissionRequest.Operation == admissionv1beta1.Update {
+		oldNetworkAddonsConfig, err := decodeConfig(v.codecs, req.AdmissionRequest.OldObject.Raw)
+		if err != nil {
+			return admission.ErrorResponse(http.StatusBadRequest, err)
+		}
+		if reflect.DeepEqual(oldNetworkAddonsConfig.Spec, networkAddonsConfig.Spec) {
+			return admission.ValidationResponse(true, "")
+		}
+	}
+
+	if _, _, err := validateConfig(ctx, v.client, networkAddonsConfig, v.namespace, v.clusterInfo); err != nil {
+		return admission.ErrorResponse(http.StatusForbidden, err)
+	}
+
+	return admission.ValidationResponse(true, "")
+}
+
+// configRules returns rules matching NetworkAddonsConfig changes in all served API versions
+func configRules() []admissionregistrationv1beta1.RuleWithOperations {
+	return []admissionregistrationv1beta1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1beta1.OperationType{
+				admissionregistrationv1beta1.Create,
+				admissionregistrationv1beta1.Update,
+			},
+			Rule: admissionregistrationv1beta1.Rule{
+				APIGroups: []string{opv1.SchemeGroupVersion.Group},
+				APIVersions: []string{
+					opv1.SchemeGroupVersion.Version,
+					opv1alpha1.SchemeGroupVersion.Version,
+				},
+				Resources: []string{"networkaddonsconfigs"},
+			},
+		},
+	}
+}
+
+// decodeConfig decodes NetworkAddonsConfig of any served API version and converts it to v1,
+// which is the version used internally by the operator
+func decodeConfig(codecs serializer.CodecFactory, raw []byte) (*opv1.NetworkAddonsConfig, error) {
+	obj, err := runtime.Decode(codecs.UniversalDecoder(opv1.SchemeGroupVersion), raw)
+	if err != nil {
+		return nil, err
+	}
+
+	networkAddonsConfig, ok := obj.(*opv1.NetworkAddonsConfig)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object %s, expected NetworkAddonsConfig", obj.GetObjectKind().GroupVersionKind())
+	}
+
+	return networkAddonsConfig, nil
+}
@@ -0,0 +1,71 @@
+package statusmanager
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// failingWaitingReasons are reasons of waiting containers which are not going to start without
+// an intervention, e.g. a fixed image reference or configuration. Pods with such containers make
+// their workload Degraded instead of Progressing
+var failingWaitingReasons = map[string]bool{
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+}
+
+// podFailure describes a container of a pod which is stuck in one of failingWaitingReasons
+type podFailure struct {
+	reason  string
+	message string
+}
+
+// findPodFailure returns the first stuck container of pods matching the selector, or nil if there
+// is none. Pods are listed from the cache of pods of components, other pods of the cluster are not
+// cached by the operator
+func (status *StatusManager) findPodFailure(namespace string, selector *metav1.LabelSelector) (*podFailure, error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := status.pods.Pods(namespace).List(labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pod := range pods {
+		if failure := failureOfPod(pod); failure != nil {
+			return failure, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// failureOfPod checks init and regular containers of the pod for one which is stuck
+func failureOfPod(pod *corev1.Pod) *podFailure {
+	containerStatuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	containerStatuses = append(containerStatuses, pod.Status.ContainerStatuses...)
+
+	for _, containerStatus := range containerStatuses {
+		waiting := containerStatus.State.Waiting
+		if waiting == nil || !failingWaitingReasons[waiting.Reason] {
+			continue
+		}
+
+		message := fmt.Sprintf("pod %q on node %q: container %q is waiting with reason %s",
+			types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}.String(), pod.Spec.NodeName, containerStatus.Name, waiting.Reason)
+		if waiting.Message != "" {
+			message += ": " + waiting.Message
+		}
+		return &podFailure{reason: waiting.Reason, message: message}
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
@@ -14,10 +15,15 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	opv1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/metrics"
 )
 
 const (
@@ -27,6 +33,9 @@ const (
 
 var operatorVersion string
 
+// now is used to measure how long are workloads rolling out, it is replaced in tests
+var now = time.Now
+
 func init() {
 	operatorVersion = os.Getenv("OPERATOR_VERSION")
 }
@@ -45,27 +54,101 @@ const (
 
 // StatusManager coordinates changes to NetworkAddonsConfig.Status
 type StatusManager struct {
-	client client.Client
-	name   string
+	client   client.Client
+	pods     corelisters.PodLister
+	recorder record.EventRecorder
+	name     string
 
 	failing [maxStatusLevel]*conditionsv1.Condition
 
-	daemonSets  []types.NamespacedName
-	deployments []types.NamespacedName
+	workloads []Workload
+
+	// Observed state of components, derived from their workloads
+	components []componentState
+
+	// Objects of removed components which may still exist on the cluster
+	removedObjects []*unstructured.Unstructured
+
+	// Set while components of a deleted config are being torn down
+	tearingDown bool
+
+	containers []opv1.Container
+
+	patches []opv1.AppliedPatch
+
+	// Maximum duration of rollouts of components, keyed by component name, and rollouts of
+	// workloads which are in progress
+	progressDeadlines map[string]time.Duration
+	rollouts          map[Workload]rollout
 
-	containers []opv1alpha1.Container
+	// Both the config and the pod reconcilers change the state above, so it is guarded by lock.
+	// It is held by every exported method for its whole duration, so the reported status is
+	// always derived from a consistent snapshot
+	lock sync.Mutex
 }
 
-func New(client client.Client, name string) *StatusManager {
-	return &StatusManager{client: client, name: name}
+// Workload is a DaemonSet or Deployment of a deployed component
+type Workload struct {
+	Component string
+	Kind      string
+	Name      types.NamespacedName
 }
 
-// Set updates the NetworkAddonsConfig.Status with the provided conditions.
+// rollout marks when a workload started progressing. A new generation of the workload starts a
+// new rollout
+type rollout struct {
+	generation int64
+	since      time.Time
+}
+
+// componentState is the observed state of a component. Its conditions are merged into those
+// already reported in the status, so their transition times are kept
+type componentState struct {
+	name        string
+	workloads   []opv1.WorkloadStatus
+	failure     *workloadState
+	progressing []string
+}
+
+// workloadState is the observed state of a single workload. A workload which is failing, e.g. it
+// does not exist, has failureReason set, a workload which is being rolled out has progressing set
+type workloadState struct {
+	status         opv1.WorkloadStatus
+	object         runtime.Object
+	progressing    string
+	failureReason  string
+	failureMessage string
+}
+
+// componentEvent is an event about a changed state of a component. It is recorded on the
+// NetworkAddonsConfig and on the affected workload, if there is any
+type componentEvent struct {
+	eventType string
+	reason    string
+	message   string
+	workload  runtime.Object
+}
+
+// New returns a StatusManager of the NetworkAddonsConfig with the given name. Transitions of
+// components are reported to the recorder if one is given
+func New(client client.Client, pods corelisters.PodLister, recorder record.EventRecorder, name string) *StatusManager {
+	return &StatusManager{client: client, pods: pods, recorder: recorder, name: name, rollouts: map[Workload]rollout{}}
+}
+
+// Set updates the NetworkAddonsConfig.Status with the provided conditions
+func (status *StatusManager) Set(reachedAvailableLevel bool, conditions ...conditionsv1.Condition) {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	status.setConditions(reachedAvailableLevel, conditions...)
+}
+
+// setConditions updates the NetworkAddonsConfig.Status with the provided conditions.
 // Since Update call can fail due to a collision with someone else writing into
 // the status, calling set is tried several times.
 // TODO: Calling of Patch instead may save some problems. We can reiterate later,
 // current collision problem is detected by functional tests
-func (status *StatusManager) Set(reachedAvailableLevel bool, conditions ...conditionsv1.Condition) {
+func (status *StatusManager) setConditions(reachedAvailableLevel bool, conditions ...conditionsv1.Condition) {
 	for i := 0; i < conditionsUpdateRetries; i++ {
 		err := status.set(reachedAvailableLevel, conditions...)
 		if err == nil {
@@ -81,7 +164,7 @@ func (status *StatusManager) Set(reachedAvailableLevel bool, conditions ...condi
 // set updates the NetworkAddonsConfig.Status with the provided conditions
 func (status *StatusManager) set(reachedAvailableLevel bool, conditions ...conditionsv1.Condition) error {
 	// Read the current NetworkAddonsConfig
-	config := &opv1alpha1.NetworkAddonsConfig{ObjectMeta: metav1.ObjectMeta{Name: status.name}}
+	config := &opv1.NetworkAddonsConfig{ObjectMeta: metav1.ObjectMeta{Name: status.name}}
 	err := status.client.Get(context.TODO(), types.NamespacedName{Name: status.name}, config)
 	if err != nil {
 		log.Printf("Failed to get NetworkAddonsOperator %q in order to update its State: %v", status.name, err)
@@ -96,7 +179,18 @@ func (status *StatusManager) set(reachedAvailableLevel bool, conditions ...condi
 	}
 
 	// Glue condition logic together
-	if status.failing[OperatorConfig] != nil {
+	if status.tearingDown {
+		// Deleted config is never Available again, progress of the teardown is reported in the
+		// Progressing condition
+		conditionsv1.SetStatusCondition(&config.Status.Conditions,
+			conditionsv1.Condition{
+				Type:    conditionsv1.ConditionAvailable,
+				Status:  corev1.ConditionFalse,
+				Reason:  "TearingDown",
+				Message: "Deployed components are being removed",
+			},
+		)
+	} else if status.failing[OperatorConfig] != nil {
 		// In case the operator is failing, we should not report it as being ready. This has
 		// to be done even when the operator is running fine based on the previous configuration
 		// and the only failing thing is validation of new config.
@@ -153,6 +247,13 @@ func (status *StatusManager) set(reachedAvailableLevel bool, conditions ...condi
 	// Make sure to expose deployed containers
 	config.Status.Containers = status.containers
 
+	// Report state of each component, keeping transition times of their conditions
+	config.Status.Components = componentStatuses(oldStatus.Components, status.components)
+	events := componentEvents(oldStatus.Components, status.components)
+
+	// List patches customizing deployed objects
+	config.Status.Patches = status.patches
+
 	// Expose currently handled version
 	config.Status.OperatorVersion = operatorVersion
 	config.Status.TargetVersion = operatorVersion
@@ -160,6 +261,9 @@ func (status *StatusManager) set(reachedAvailableLevel bool, conditions ...condi
 	// Failing condition had been replaced by Degraded in 0.12.0, drop it from CR if needed
 	conditionsv1.RemoveStatusCondition(&config.Status.Conditions, conditionsv1.ConditionType("Failing"))
 
+	metrics.SetOperatorVersion(operatorVersion, config.Status.ObservedVersion)
+	metrics.SetDegraded(conditionsv1.IsStatusConditionTrue(config.Status.Conditions, conditionsv1.ConditionDegraded))
+
 	if reflect.DeepEqual(oldStatus, config.Status) {
 		return nil
 	}
@@ -170,18 +274,35 @@ func (status *StatusManager) set(reachedAvailableLevel bool, conditions ...condi
 		return fmt.Errorf("Failed to update NetworkAddonsConfig %q Status: %v", config.Name, err)
 	}
 
+	// Changes of components are reported only once their status is stored
+	status.recordEvents(config, events)
+
 	return nil
 }
 
+// recordEvents reports events about changed state of components on the config and on their
+// affected workloads
+func (status *StatusManager) recordEvents(config *opv1.NetworkAddonsConfig, events []componentEvent) {
+	if status.recorder == nil {
+		return
+	}
+	for _, event := range events {
+		status.recorder.Event(config, event.eventType, event.reason, event.message)
+		if event.workload != nil {
+			status.recorder.Event(event.workload, event.eventType, event.reason, event.message)
+		}
+	}
+}
+
 // syncFailing syncs the current Failing status
 func (status *StatusManager) syncFailing() {
 	for _, c := range status.failing {
 		if c != nil {
-			status.Set(false, *c)
+			status.setConditions(false, *c)
 			return
 		}
 	}
-	status.Set(
+	status.setConditions(
 		false,
 		conditionsv1.Condition{
 			Type:   conditionsv1.ConditionDegraded,
@@ -193,6 +314,13 @@ func (status *StatusManager) syncFailing() {
 // SetFailing marks the operator as Failing with the given reason and message. If it
 // is not already failing for a lower-level reason, the operator's status will be updated.
 func (status *StatusManager) SetFailing(level StatusLevel, reason, message string) {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	status.setFailing(level, reason, message)
+}
+
+func (status *StatusManager) setFailing(level StatusLevel, reason, message string) {
 	status.failing[level] = &conditionsv1.Condition{
 		Type:    conditionsv1.ConditionDegraded,
 		Status:  corev1.ConditionTrue,
@@ -206,140 +334,548 @@ func (status *StatusManager) SetFailing(level StatusLevel, reason, message strin
 // status previously indicated failure at this level, it will updated to show the next
 // higher-level failure, or else to show that the operator is no longer failing.
 func (status *StatusManager) SetNotFailing(level StatusLevel) {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	status.setNotFailing(level)
+}
+
+func (status *StatusManager) setNotFailing(level StatusLevel) {
 	if status.failing[level] != nil {
 		status.failing[level] = nil
 	}
 	status.syncFailing()
 }
 
-func (status *StatusManager) SetDaemonSets(daemonSets []types.NamespacedName) {
-	status.daemonSets = daemonSets
+// SetWorkloads sets DaemonSets and Deployments of deployed components, their state is reported in
+// the status
+func (status *StatusManager) SetWorkloads(workloads []Workload) {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	status.workloads = workloads
+}
+
+// SetProgressDeadlines sets maximum duration of rollouts of components, keyed by component name.
+// Workloads of components which exceed it are reported as Degraded
+func (status *StatusManager) SetProgressDeadlines(deadlines map[string]time.Duration) {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	status.progressDeadlines = deadlines
+}
+
+// UntilProgressDeadline returns time remaining until the nearest progress deadline of rollouts
+// which are still in progress, so the status can be checked again once it passes. False is
+// returned if there is no such rollout
+func (status *StatusManager) UntilProgressDeadline() (time.Duration, bool) {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	nearest := time.Duration(0)
+	found := false
+	for workload, started := range status.rollouts {
+		deadline, configured := status.progressDeadlines[workload.Component]
+		if !configured {
+			continue
+		}
+		remaining := started.since.Add(deadline).Sub(now())
+		if remaining > 0 && (!found || remaining < nearest) {
+			nearest = remaining
+			found = true
+		}
+	}
+	return nearest, found
+}
+
+// AddRemovedObjects marks objects of removed components as being removed. They are reported as
+// Progressing until they disappear from the cluster. Removed DaemonSets and Deployments are not
+// tracked as deployed anymore.
+func (status *StatusManager) AddRemovedObjects(objs []*unstructured.Unstructured) {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	for _, obj := range objs {
+		name := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		status.workloads = withoutWorkload(status.workloads, obj.GetKind(), name)
+		status.removedObjects = append(status.removedObjects, obj)
+	}
+}
+
+// IsRemoving reports whether some objects of removed components are still present on the cluster
+func (status *StatusManager) IsRemoving() bool {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	return len(status.removedObjects) > 0
+}
+
+// SetTearingDown reports progress of teardown of components of a deleted config. From now on, the
+// status is not updated based on deployed pods anymore.
+func (status *StatusManager) SetTearingDown(message string) {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	status.tearingDown = true
+	status.removedObjects = nil
+	status.components = nil
+	status.forgetRollouts(nil)
+	metrics.SetComponents(nil)
+	status.setConditions(
+		false,
+		conditionsv1.Condition{
+			Type:    conditionsv1.ConditionProgressing,
+			Status:  corev1.ConditionTrue,
+			Reason:  "TearingDown",
+			Message: message,
+		},
+	)
 }
 
-func (status *StatusManager) SetDeployments(deployments []types.NamespacedName) {
-	status.deployments = deployments
+// SetTornDown marks the teardown as finished, so a newly created config starts from scratch
+func (status *StatusManager) SetTornDown() {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	status.tearingDown = false
+	status.removedObjects = nil
 }
 
 // SetFromPods sets the operator status to Failing, Progressing, or Available, based on
 // the current status of the manager's DaemonSets and Deployments. However, this is a
 // no-op if the StatusManager is currently marked as failing due to a configuration error.
 func (status *StatusManager) SetFromPods() {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	if status.tearingDown {
+		return
+	}
+
+	// Rollouts of workloads which are not deployed anymore are not tracked
+	status.forgetRollouts(status.workloads)
+
+	// Check state of all owned workloads and group it by their components
 	progressing := []string{}
+	var failure *workloadState
+	components := []*componentState{}
+	componentByName := map[string]*componentState{}
+	for _, workload := range status.workloads {
+		state := status.checkWorkload(workload)
+
+		component, found := componentByName[workload.Component]
+		if !found {
+			component = &componentState{name: workload.Component}
+			componentByName[workload.Component] = component
+			components = append(components, component)
+		}
+		component.addWorkload(state)
 
-	// Iterate all owned DaemonSets and check whether they are progressing smoothly or have been
-	// already deployed.
-	for _, dsName := range status.daemonSets {
+		if state.failureReason != "" && failure == nil {
+			failure = &state
+		} else if state.progressing != "" {
+			progressing = append(progressing, state.progressing)
+		}
+	}
 
-		// First check whether DaemonSet namespace exists
-		ns := &corev1.Namespace{}
-		if err := status.client.Get(context.TODO(), types.NamespacedName{Name: dsName.Namespace}, ns); err != nil {
-			if errors.IsNotFound(err) {
-				status.SetFailing(PodDeployment, "NoNamespace",
-					fmt.Sprintf("Namespace %q does not exist", dsName.Namespace))
-			} else {
-				status.SetFailing(PodDeployment, "InternalError",
-					fmt.Sprintf("Internal error deploying pods: %v", err))
-			}
-			return
+	status.components = []componentState{}
+	readiness := []metrics.ComponentReadiness{}
+	for _, component := range components {
+		status.components = append(status.components, *component)
+		readiness = append(readiness, component.readiness())
+	}
+	metrics.SetComponents(readiness)
+
+	// The first failing workload marks the whole deployment as failing
+	if failure != nil {
+		status.setFailing(PodDeployment, failure.failureReason, failure.failureMessage)
+		return
+	}
+
+	// Check whether objects of removed components are gone already, forget those which are
+	removing := status.checkRemovedObjects()
+
+	// If there are any progressing Pods, list them in the condition with their state. The same
+	// goes for objects being removed. Otherwise, mark Progressing condition as False.
+	if len(progressing) > 0 {
+		status.setConditions(
+			false,
+			conditionsv1.Condition{
+				Type:    conditionsv1.ConditionProgressing,
+				Status:  corev1.ConditionTrue,
+				Reason:  "Deploying",
+				Message: strings.Join(append(progressing, removing...), "\n"),
+			},
+		)
+	} else if len(removing) > 0 {
+		status.setConditions(
+			false,
+			conditionsv1.Condition{
+				Type:    conditionsv1.ConditionProgressing,
+				Status:  corev1.ConditionTrue,
+				Reason:  "Removing",
+				Message: strings.Join(removing, "\n"),
+			},
+		)
+	} else {
+		status.setConditions(
+			false,
+			conditionsv1.Condition{
+				Type:   conditionsv1.ConditionProgressing,
+				Status: corev1.ConditionFalse,
+			},
+		)
+	}
+
+	// If all pods are being created, mark deployment as not failing
+	status.setNotFailing(PodDeployment)
+
+	// Finally, if all containers are deployed and removed components are gone, mark as Available
+	if len(progressing) == 0 && len(removing) == 0 {
+		status.setConditions(true)
+	}
+}
+
+// checkRemovedObjects forgets objects of removed components which are gone already and describes
+// those which are still being removed
+func (status *StatusManager) checkRemovedObjects() []string {
+	removing := []string{}
+	pending := []*unstructured.Unstructured{}
+	for _, obj := range status.removedObjects {
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(obj.GroupVersionKind())
+		err := status.client.Get(context.TODO(), types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, existing)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			log.Printf("Failed to check whether (%s) %s/%s was removed: %v", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), err)
 		}
+		pending = append(pending, obj)
+		removing = append(removing, fmt.Sprintf("%s %q is being removed", obj.GetKind(), types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}.String()))
+	}
+	status.removedObjects = pending
+
+	return removing
+}
+
+func (status *StatusManager) SetContainers(containers []opv1.Container) {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	status.containers = containers
+}
+
+func (status *StatusManager) SetPatches(patches []opv1.AppliedPatch) {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+
+	status.patches = patches
+}
 
+// checkWorkload checks whether the workload exists and whether its pods are rolled out
+func (status *StatusManager) checkWorkload(workload Workload) workloadState {
+	name := workload.Name
+	state := workloadState{
+		status: opv1.WorkloadStatus{Kind: workload.Kind, Namespace: name.Namespace, Name: name.Name},
+	}
+
+	// First check whether the namespace of the workload exists
+	ns := &corev1.Namespace{}
+	if err := status.client.Get(context.TODO(), types.NamespacedName{Name: name.Namespace}, ns); err != nil {
+		if errors.IsNotFound(err) {
+			state.failureReason = "NoNamespace"
+			state.failureMessage = fmt.Sprintf("Namespace %q does not exist", name.Namespace)
+		} else {
+			state.failureReason = "InternalError"
+			state.failureMessage = fmt.Sprintf("Internal error deploying pods: %v", err)
+		}
+		return state
+	}
+
+	switch workload.Kind {
+	case "DaemonSet":
 		// Then check whether is the DaemonSet created on Kubernetes API server
 		ds := &appsv1.DaemonSet{}
-		if err := status.client.Get(context.TODO(), dsName, ds); err != nil {
+		if err := status.client.Get(context.TODO(), name, ds); err != nil {
 			if errors.IsNotFound(err) {
-				status.SetFailing(PodDeployment, "NoDaemonSet",
-					fmt.Sprintf("Expected DaemonSet %q does not exist", dsName.String()))
+				state.failureReason = "NoDaemonSet"
+				state.failureMessage = fmt.Sprintf("Expected DaemonSet %q does not exist", name.String())
 			} else {
-				status.SetFailing(PodDeployment, "InternalError",
-					fmt.Sprintf("Internal error deploying pods: %v", err))
+				state.failureReason = "InternalError"
+				state.failureMessage = fmt.Sprintf("Internal error deploying pods: %v", err)
 			}
-			return
+			return state
 		}
 
+		state.object = ds
+		state.status.Desired = ds.Status.DesiredNumberScheduled
+		state.status.Ready = ds.Status.NumberAvailable
+
 		// Finally check whether Pods belonging to this DaemonSets are being started or they
 		// are being scheduled.
 		if ds.Status.NumberUnavailable > 0 {
-			progressing = append(progressing, fmt.Sprintf("DaemonSet %q is not available (awaiting %d nodes)", dsName.String(), ds.Status.NumberUnavailable))
+			state.progressing = fmt.Sprintf("DaemonSet %q is not available (awaiting %d nodes)", name.String(), ds.Status.NumberUnavailable)
 		} else if ds.Status.NumberAvailable == 0 {
-			progressing = append(progressing, fmt.Sprintf("DaemonSet %q is not yet scheduled on any nodes", dsName.String()))
+			state.progressing = fmt.Sprintf("DaemonSet %q is not yet scheduled on any nodes", name.String())
 		} else if ds.Status.UpdatedNumberScheduled < ds.Status.DesiredNumberScheduled {
-			progressing = append(progressing, fmt.Sprintf("DaemonSet %q update is rolling out (%d out of %d updated)", dsName.String(), ds.Status.UpdatedNumberScheduled, ds.Status.DesiredNumberScheduled))
+			state.progressing = fmt.Sprintf("DaemonSet %q update is rolling out (%d out of %d updated)", name.String(), ds.Status.UpdatedNumberScheduled, ds.Status.DesiredNumberScheduled)
 		} else if ds.Generation > ds.Status.ObservedGeneration {
-			progressing = append(progressing, fmt.Sprintf("DaemonSet %q update is being processed (generation %d, observed generation %d)", dsName.String(), ds.Generation, ds.Status.ObservedGeneration))
+			state.progressing = fmt.Sprintf("DaemonSet %q update is being processed (generation %d, observed generation %d)", name.String(), ds.Generation, ds.Status.ObservedGeneration)
 		}
-	}
 
-	// Do the same for Deployments. Iterate all owned Deployments and check whether they are
-	// progressing smoothly or have been already deployed.
-	for _, depName := range status.deployments {
-		// First check whether Deployment namespace exists
-		ns := &corev1.Namespace{}
-		if err := status.client.Get(context.TODO(), types.NamespacedName{Name: depName.Namespace}, ns); err != nil {
-			if errors.IsNotFound(err) {
-				status.SetFailing(PodDeployment, "NoNamespace",
-					fmt.Sprintf("Namespace %q does not exist", depName.Namespace))
-			} else {
-				status.SetFailing(PodDeployment, "InternalError",
-					fmt.Sprintf("Internal error deploying pods: %v", err))
-			}
-			return
+		if state.progressing != "" {
+			status.checkPods(&state, name, ds.Spec.Selector)
 		}
-
+		status.checkProgressDeadline(workload, &state, ds.Generation)
+	case "Deployment":
 		// Then check whether is the Deployment created on Kubernetes API server
 		dep := &appsv1.Deployment{}
-		if err := status.client.Get(context.TODO(), depName, dep); err != nil {
+		if err := status.client.Get(context.TODO(), name, dep); err != nil {
 			if errors.IsNotFound(err) {
-				status.SetFailing(PodDeployment, "NoDeployment",
-					fmt.Sprintf("Expected Deployment %q does not exist", depName.String()))
+				state.failureReason = "NoDeployment"
+				state.failureMessage = fmt.Sprintf("Expected Deployment %q does not exist", name.String())
 			} else {
-				status.SetFailing(PodDeployment, "InternalError",
-					fmt.Sprintf("Internal error deploying pods: %v", err))
+				state.failureReason = "InternalError"
+				state.failureMessage = fmt.Sprintf("Internal error deploying pods: %v", err)
 			}
-			return
+			return state
+		}
+
+		state.object = dep
+		state.status.Desired = 1
+		if dep.Spec.Replicas != nil {
+			state.status.Desired = *dep.Spec.Replicas
 		}
+		state.status.Ready = dep.Status.AvailableReplicas
 
 		// Finally check whether Pods belonging to this Deployments are being started or they
 		// are being scheduled.
 		if dep.Status.UnavailableReplicas > 0 {
-			progressing = append(progressing, fmt.Sprintf("Deployment %q is not available (awaiting %d nodes)", depName.String(), dep.Status.UnavailableReplicas))
+			state.progressing = fmt.Sprintf("Deployment %q is not available (awaiting %d nodes)", name.String(), dep.Status.UnavailableReplicas)
 		} else if dep.Status.AvailableReplicas == 0 {
-			progressing = append(progressing, fmt.Sprintf("Deployment %q is not yet scheduled on any nodes", depName.String()))
+			state.progressing = fmt.Sprintf("Deployment %q is not yet scheduled on any nodes", name.String())
 		} else if dep.Status.ObservedGeneration < dep.Generation {
-			progressing = append(progressing, fmt.Sprintf("Deployment %q update is being processed (generation %d, observed generation %d)", depName.String(), dep.Generation, dep.Status.ObservedGeneration))
+			state.progressing = fmt.Sprintf("Deployment %q update is being processed (generation %d, observed generation %d)", name.String(), dep.Generation, dep.Status.ObservedGeneration)
 		}
+
+		if state.progressing != "" {
+			status.checkPods(&state, name, dep.Spec.Selector)
+		}
+		status.checkProgressDeadline(workload, &state, dep.Generation)
 	}
 
-	// If there are any progressing Pods, list them in the condition with their state. Otherwise,
-	// mark Progressing condition as False.
-	if len(progressing) > 0 {
-		status.Set(
-			false,
-			conditionsv1.Condition{
-				Type:    conditionsv1.ConditionProgressing,
-				Status:  corev1.ConditionTrue,
-				Reason:  "Deploying",
-				Message: strings.Join(progressing, "\n"),
-			},
-		)
+	return state
+}
+
+// checkPods looks for pods of a workload which is being rolled out, which are stuck and won't
+// start by themselves, e.g. due to a wrong image. If there is any, the workload is failing rather
+// than progressing
+func (status *StatusManager) checkPods(state *workloadState, name types.NamespacedName, selector *metav1.LabelSelector) {
+	failure, err := status.findPodFailure(name.Namespace, selector)
+	if err != nil {
+		log.Printf("Failed to check pods of %s %q: %v", state.status.Kind, name.String(), err)
+		return
+	}
+	if failure == nil {
+		return
+	}
+
+	state.progressing = ""
+	state.failureReason = failure.reason
+	state.failureMessage = fmt.Sprintf("%s %q has a failing %s", state.status.Kind, name.String(), failure.message)
+}
+
+// checkProgressDeadline tracks when the workload started progressing. If its rollout takes longer
+// than the progress deadline of its component, the workload is failing rather than progressing.
+// Rollouts of workloads which are failing are kept, so their time is not reset
+func (status *StatusManager) checkProgressDeadline(workload Workload, state *workloadState, generation int64) {
+	if state.failureReason != "" {
+		return
+	}
+	if state.progressing == "" {
+		delete(status.rollouts, workload)
+		return
+	}
+
+	started, found := status.rollouts[workload]
+	if !found || started.generation != generation {
+		started = rollout{generation: generation, since: now()}
+		status.rollouts[workload] = started
+	}
+
+	deadline, configured := status.progressDeadlines[workload.Component]
+	if !configured || now().Sub(started.since) < deadline {
+		return
+	}
+
+	state.failureReason = "RolloutTimedOut"
+	state.failureMessage = fmt.Sprintf("%s %q has not finished rolling out within %s: %s", workload.Kind, workload.Name.String(), deadline, state.progressing)
+	state.progressing = ""
+}
+
+// forgetRollouts drops tracked rollouts of all workloads but the given ones
+func (status *StatusManager) forgetRollouts(workloads []Workload) {
+	tracked := map[Workload]rollout{}
+	for _, workload := range workloads {
+		if started, found := status.rollouts[workload]; found {
+			tracked[workload] = started
+		}
+	}
+	status.rollouts = tracked
+}
+
+func (component *componentState) addWorkload(state workloadState) {
+	component.workloads = append(component.workloads, state.status)
+	if state.failureReason != "" && component.failure == nil {
+		component.failure = &state
+	} else if state.progressing != "" {
+		component.progressing = append(component.progressing, state.progressing)
+	}
+}
+
+func (component *componentState) isAvailable() bool {
+	return component.failure == nil && len(component.progressing) == 0
+}
+
+// readiness sums pods of all workloads of the component, so it can be exposed in metrics
+func (component *componentState) readiness() metrics.ComponentReadiness {
+	readiness := metrics.ComponentReadiness{Name: component.name, Ready: component.isAvailable()}
+	for _, workload := range component.workloads {
+		readiness.DesiredPods += workload.Desired
+		readiness.ReadyPods += workload.Ready
+	}
+	return readiness
+}
+
+// conditions derives conditions of the component from the first failure of its workloads and
+// from the list of its workloads which are being rolled out
+func (component *componentState) conditions() []conditionsv1.Condition {
+	conditions := []conditionsv1.Condition{}
+
+	if component.failure != nil {
+		conditions = append(conditions, conditionsv1.Condition{
+			Type:    conditionsv1.ConditionDegraded,
+			Status:  corev1.ConditionTrue,
+			Reason:  component.failure.failureReason,
+			Message: component.failure.failureMessage,
+		})
 	} else {
-		status.Set(
-			false,
-			conditionsv1.Condition{
-				Type:   conditionsv1.ConditionProgressing,
-				Status: corev1.ConditionFalse,
-			},
-		)
+		conditions = append(conditions, conditionsv1.Condition{
+			Type:   conditionsv1.ConditionDegraded,
+			Status: corev1.ConditionFalse,
+		})
 	}
 
-	// If all pods are being created, mark deployment as not failing
-	status.SetNotFailing(PodDeployment)
+	if len(component.progressing) > 0 {
+		conditions = append(conditions, conditionsv1.Condition{
+			Type:    conditionsv1.ConditionProgressing,
+			Status:  corev1.ConditionTrue,
+			Reason:  "Deploying",
+			Message: strings.Join(component.progressing, "\n"),
+		})
+	} else {
+		conditions = append(conditions, conditionsv1.Condition{
+			Type:   conditionsv1.ConditionProgressing,
+			Status: corev1.ConditionFalse,
+		})
+	}
 
-	// Finally, if all containers are deployed, mark as Available
-	if len(progressing) == 0 {
-		status.Set(true)
+	if component.failure != nil {
+		conditions = append(conditions, conditionsv1.Condition{
+			Type:    conditionsv1.ConditionAvailable,
+			Status:  corev1.ConditionFalse,
+			Reason:  "Failing",
+			Message: "Some problems occurred while deploying component's pods",
+		})
+	} else if len(component.progressing) > 0 {
+		conditions = append(conditions, conditionsv1.Condition{
+			Type:    conditionsv1.ConditionAvailable,
+			Status:  corev1.ConditionFalse,
+			Reason:  "Startup",
+			Message: "Component's pods are being deployed",
+		})
+	} else {
+		conditions = append(conditions, conditionsv1.Condition{
+			Type:   conditionsv1.ConditionAvailable,
+			Status: corev1.ConditionTrue,
+		})
 	}
+
+	return conditions
 }
 
-func (status *StatusManager) SetContainers(containers []opv1alpha1.Container) {
-	status.containers = containers
+// componentStatuses merges observed state of components into their previously reported statuses.
+// Conditions keep their transition times and a component reports the version of the operator
+// which last made it available
+func componentStatuses(reported []opv1.ComponentStatus, components []componentState) []opv1.ComponentStatus {
+	if len(components) == 0 {
+		return nil
+	}
+
+	previous := map[string]opv1.ComponentStatus{}
+	for _, component := range reported {
+		previous[component.Name] = component
+	}
+
+	statuses := []opv1.ComponentStatus{}
+	for _, component := range components {
+		componentStatus := opv1.ComponentStatus{Name: component.name, Workloads: component.workloads}
+		if old, found := previous[component.name]; found {
+			componentStatus.Version = old.Version
+			componentStatus.Conditions = append([]conditionsv1.Condition{}, old.Conditions...)
+		}
+		for _, condition := range component.conditions() {
+			conditionsv1.SetStatusCondition(&componentStatus.Conditions, condition)
+		}
+		if component.isAvailable() {
+			componentStatus.Version = operatorVersion
+		}
+		statuses = append(statuses, componentStatus)
+	}
+	return statuses
+}
+
+// componentEvents compares observed state of components with their previously reported statuses.
+// Components which became available and those which started failing, or are failing for another
+// reason than before, are reported
+func componentEvents(reported []opv1.ComponentStatus, components []componentState) []componentEvent {
+	previous := map[string]opv1.ComponentStatus{}
+	for _, component := range reported {
+		previous[component.Name] = component
+	}
+
+	events := []componentEvent{}
+	for _, component := range components {
+		old := previous[component.name]
+		if component.isAvailable() && !conditionsv1.IsStatusConditionTrue(old.Conditions, conditionsv1.ConditionAvailable) {
+			events = append(events, componentEvent{
+				eventType: corev1.EventTypeNormal,
+				reason:    "ComponentReady",
+				message:   fmt.Sprintf("Component %s is ready", component.name),
+			})
+		}
+		if component.failure != nil {
+			oldDegraded := conditionsv1.FindStatusCondition(old.Conditions, conditionsv1.ConditionDegraded)
+			if oldDegraded == nil || oldDegraded.Status != corev1.ConditionTrue || oldDegraded.Reason != component.failure.failureReason {
+				events = append(events, componentEvent{
+					eventType: corev1.EventTypeWarning,
+					reason:    "ComponentDegraded",
+					message:   fmt.Sprintf("Component %s is degraded: %s", component.name, component.failure.failureMessage),
+					workload:  component.failure.object,
+				})
+			}
+		}
+	}
+	return events
+}
+
+func withoutWorkload(workloads []Workload, kind string, name types.NamespacedName) []Workload {
+	filtered := []Workload{}
+	for _, workload := range workloads {
+		if workload.Kind != kind || workload.Name != name {
+			filtered = append(filtered, workload)
+		}
+	}
+	return filtered
 }
@@ -0,0 +1,434 @@
+package statusmanager
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apis"
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+var _ = Describe("Testing status manager", func() {
+	const namespace = "cluster-network-addons"
+
+	var c client.Client
+	var pods cache.Indexer
+	var status *StatusManager
+
+	daemonSet := func(name string, desired, available int32) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       appsv1.DaemonSetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"name": name}}},
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: desired,
+				UpdatedNumberScheduled: desired,
+				NumberAvailable:        available,
+				NumberUnavailable:      desired - available,
+			},
+		}
+	}
+
+	deployment := func(name string, replicas, available int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status: appsv1.DeploymentStatus{
+				AvailableReplicas:   available,
+				UnavailableReplicas: replicas - available,
+			},
+		}
+	}
+
+	stuckPod := func(daemonSet string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: daemonSet + "-x7k2p", Labels: map[string]string{"name": daemonSet}},
+			Spec:       corev1.PodSpec{NodeName: "node01"},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "ovs-cni-marker",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"}},
+				}},
+			},
+		}
+	}
+
+	workload := func(component, kind, name string) Workload {
+		return Workload{Component: component, Kind: kind, Name: types.NamespacedName{Namespace: namespace, Name: name}}
+	}
+
+	getConfig := func() *opv1.NetworkAddonsConfig {
+		config := &opv1.NetworkAddonsConfig{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Name: "cluster"}, config)).To(Succeed())
+		return config
+	}
+
+	componentStatus := func(config *opv1.NetworkAddonsConfig, name string) opv1.ComponentStatus {
+		for _, component := range config.Status.Components {
+			if component.Name == name {
+				return component
+			}
+		}
+		Fail("component " + name + " is not reported")
+		return opv1.ComponentStatus{}
+	}
+
+	newStatusManager := func(recorder record.EventRecorder, componentPods ...*corev1.Pod) *StatusManager {
+		pods = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		for _, pod := range componentPods {
+			Expect(pods.Add(pod)).To(Succeed())
+		}
+		return New(c, corelisters.NewPodLister(pods), recorder, "cluster")
+	}
+
+	newClient := func(objs ...runtime.Object) client.Client {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(apis.AddToScheme(scheme)).To(Succeed())
+		objs = append(objs,
+			&opv1.NetworkAddonsConfig{ObjectMeta: metav1.ObjectMeta{Name: "cluster"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}},
+		)
+		return fake.NewFakeClientWithScheme(scheme, objs...)
+	}
+
+	Context("when all components are rolled out", func() {
+		BeforeEach(func() {
+			c = newClient(daemonSet("ovs-cni", 3, 3), deployment("kubemacpool-mac-controller-manager", 2, 2))
+			status = newStatusManager(nil)
+			status.SetWorkloads([]Workload{
+				workload("Ovs", "DaemonSet", "ovs-cni"),
+				workload("KubeMacPool", "Deployment", "kubemacpool-mac-controller-manager"),
+			})
+			status.SetFromPods()
+		})
+
+		It("should report each of them as available together with their workloads", func() {
+			config := getConfig()
+			Expect(config.Status.Components).To(HaveLen(2))
+
+			ovs := componentStatus(config, "Ovs")
+			Expect(conditionsv1.IsStatusConditionTrue(ovs.Conditions, conditionsv1.ConditionAvailable)).To(BeTrue())
+			Expect(conditionsv1.IsStatusConditionFalse(ovs.Conditions, conditionsv1.ConditionProgressing)).To(BeTrue())
+			Expect(conditionsv1.IsStatusConditionFalse(ovs.Conditions, conditionsv1.ConditionDegraded)).To(BeTrue())
+			Expect(ovs.Workloads).To(Equal([]opv1.WorkloadStatus{{Kind: "DaemonSet", Namespace: namespace, Name: "ovs-cni", Desired: 3, Ready: 3}}))
+
+			kubeMacPool := componentStatus(config, "KubeMacPool")
+			Expect(conditionsv1.IsStatusConditionTrue(kubeMacPool.Conditions, conditionsv1.ConditionAvailable)).To(BeTrue())
+			Expect(kubeMacPool.Workloads).To(Equal([]opv1.WorkloadStatus{{Kind: "Deployment", Namespace: namespace, Name: "kubemacpool-mac-controller-manager", Desired: 2, Ready: 2}}))
+
+			Expect(conditionsv1.IsStatusConditionTrue(config.Status.Conditions, conditionsv1.ConditionAvailable)).To(BeTrue())
+		})
+	})
+
+	Context("when one of components is being rolled out", func() {
+		BeforeEach(func() {
+			c = newClient(daemonSet("ovs-cni", 3, 1), daemonSet("kube-multus-ds", 3, 3))
+			status = newStatusManager(nil)
+			status.SetWorkloads([]Workload{
+				workload("Multus", "DaemonSet", "kube-multus-ds"),
+				workload("Ovs", "DaemonSet", "ovs-cni"),
+			})
+			status.SetFromPods()
+		})
+
+		It("should report only that component as progressing and derive top-level conditions from it", func() {
+			config := getConfig()
+
+			multus := componentStatus(config, "Multus")
+			Expect(conditionsv1.IsStatusConditionTrue(multus.Conditions, conditionsv1.ConditionAvailable)).To(BeTrue())
+
+			ovs := componentStatus(config, "Ovs")
+			Expect(conditionsv1.IsStatusConditionFalse(ovs.Conditions, conditionsv1.ConditionAvailable)).To(BeTrue())
+			Expect(conditionsv1.IsStatusConditionTrue(ovs.Conditions, conditionsv1.ConditionProgressing)).To(BeTrue())
+			Expect(conditionsv1.FindStatusCondition(ovs.Conditions, conditionsv1.ConditionProgressing).Message).To(ContainSubstring("awaiting 2 nodes"))
+			Expect(ovs.Workloads[0].Ready).To(Equal(int32(1)))
+			Expect(ovs.Version).To(BeEmpty())
+
+			Expect(conditionsv1.IsStatusConditionTrue(config.Status.Conditions, conditionsv1.ConditionProgressing)).To(BeTrue())
+			Expect(conditionsv1.IsStatusConditionFalse(config.Status.Conditions, conditionsv1.ConditionAvailable)).To(BeTrue())
+		})
+	})
+
+	Context("when a workload of a component is missing", func() {
+		BeforeEach(func() {
+			c = newClient(daemonSet("kube-multus-ds", 3, 3))
+			status = newStatusManager(nil)
+			status.SetWorkloads([]Workload{
+				workload("Multus", "DaemonSet", "kube-multus-ds"),
+				workload("Ovs", "DaemonSet", "ovs-cni"),
+			})
+			status.SetFromPods()
+		})
+
+		It("should report that component as degraded and keep the others available", func() {
+			config := getConfig()
+
+			multus := componentStatus(config, "Multus")
+			Expect(conditionsv1.IsStatusConditionTrue(multus.Conditions, conditionsv1.ConditionAvailable)).To(BeTrue())
+			Expect(conditionsv1.IsStatusConditionFalse(multus.Conditions, conditionsv1.ConditionDegraded)).To(BeTrue())
+
+			ovs := componentStatus(config, "Ovs")
+			degraded := conditionsv1.FindStatusCondition(ovs.Conditions, conditionsv1.ConditionDegraded)
+			Expect(degraded.Status).To(Equal(corev1.ConditionTrue))
+			Expect(degraded.Reason).To(Equal("NoDaemonSet"))
+			Expect(conditionsv1.IsStatusConditionFalse(ovs.Conditions, conditionsv1.ConditionAvailable)).To(BeTrue())
+
+			topLevelDegraded := conditionsv1.FindStatusCondition(config.Status.Conditions, conditionsv1.ConditionDegraded)
+			Expect(topLevelDegraded.Status).To(Equal(corev1.ConditionTrue))
+			Expect(topLevelDegraded.Reason).To(Equal("NoDaemonSet"))
+		})
+	})
+
+	Context("when a pod of a component is stuck pulling its image", func() {
+		BeforeEach(func() {
+			c = newClient(daemonSet("ovs-cni", 3, 2), daemonSet("kube-multus-ds", 3, 3))
+			status = newStatusManager(nil, stuckPod("ovs-cni"))
+			status.SetWorkloads([]Workload{
+				workload("Multus", "DaemonSet", "kube-multus-ds"),
+				workload("Ovs", "DaemonSet", "ovs-cni"),
+			})
+			status.SetFromPods()
+		})
+
+		It("should report that component as degraded instead of progressing, pointing to the pod", func() {
+			config := getConfig()
+
+			multus := componentStatus(config, "Multus")
+			Expect(conditionsv1.IsStatusConditionTrue(multus.Conditions, conditionsv1.ConditionAvailable)).To(BeTrue())
+
+			ovs := componentStatus(config, "Ovs")
+			Expect(conditionsv1.IsStatusConditionFalse(ovs.Conditions, conditionsv1.ConditionProgressing)).To(BeTrue())
+			degraded := conditionsv1.FindStatusCondition(ovs.Conditions, conditionsv1.ConditionDegraded)
+			Expect(degraded.Status).To(Equal(corev1.ConditionTrue))
+			Expect(degraded.Reason).To(Equal("ImagePullBackOff"))
+			Expect(degraded.Message).To(ContainSubstring(`pod "cluster-network-addons/ovs-cni-x7k2p" on node "node01"`))
+			Expect(degraded.Message).To(ContainSubstring(`container "ovs-cni-marker"`))
+
+			topLevelDegraded := conditionsv1.FindStatusCondition(config.Status.Conditions, conditionsv1.ConditionDegraded)
+			Expect(topLevelDegraded.Status).To(Equal(corev1.ConditionTrue))
+			Expect(topLevelDegraded.Reason).To(Equal("ImagePullBackOff"))
+		})
+	})
+
+	Context("when a component is rolling out for longer than its progress deadline", func() {
+		var originalNow func() time.Time
+		var currentTime time.Time
+
+		BeforeEach(func() {
+			originalNow = now
+			currentTime = time.Now()
+			now = func() time.Time { return currentTime }
+
+			c = newClient(daemonSet("ovs-cni", 3, 1), daemonSet("kube-multus-ds", 3, 1))
+			status = newStatusManager(nil)
+			status.SetProgressDeadlines(map[string]time.Duration{"Multus": 10 * time.Minute, "Ovs": 5 * time.Minute})
+			status.SetWorkloads([]Workload{
+				workload("Multus", "DaemonSet", "kube-multus-ds"),
+				workload("Ovs", "DaemonSet", "ovs-cni"),
+			})
+			status.SetFromPods()
+
+			currentTime = currentTime.Add(6 * time.Minute)
+			status.SetFromPods()
+		})
+
+		AfterEach(func() {
+			now = originalNow
+		})
+
+		It("should report only that component as degraded", func() {
+			config := getConfig()
+
+			multus := componentStatus(config, "Multus")
+			Expect(conditionsv1.IsStatusConditionTrue(multus.Conditions, conditionsv1.ConditionProgressing)).To(BeTrue())
+			Expect(conditionsv1.IsStatusConditionFalse(multus.Conditions, conditionsv1.ConditionDegraded)).To(BeTrue())
+
+			ovs := componentStatus(config, "Ovs")
+			Expect(conditionsv1.IsStatusConditionFalse(ovs.Conditions, conditionsv1.ConditionProgressing)).To(BeTrue())
+			degraded := conditionsv1.FindStatusCondition(ovs.Conditions, conditionsv1.ConditionDegraded)
+			Expect(degraded.Status).To(Equal(corev1.ConditionTrue))
+			Expect(degraded.Reason).To(Equal("RolloutTimedOut"))
+			Expect(degraded.Message).To(ContainSubstring(`DaemonSet "cluster-network-addons/ovs-cni" has not finished rolling out within 5m0s`))
+
+			topLevelDegraded := conditionsv1.FindStatusCondition(config.Status.Conditions, conditionsv1.ConditionDegraded)
+			Expect(topLevelDegraded.Status).To(Equal(corev1.ConditionTrue))
+			Expect(topLevelDegraded.Reason).To(Equal("RolloutTimedOut"))
+		})
+
+		It("should check the status again once the rollout of the other component reaches its deadline", func() {
+			untilDeadline, found := status.UntilProgressDeadline()
+			Expect(found).To(BeTrue())
+			Expect(untilDeadline).To(Equal(4 * time.Minute))
+		})
+
+		It("should clear the failure once the component recovers", func() {
+			Expect(c.Update(context.TODO(), daemonSet("ovs-cni", 3, 3))).To(Succeed())
+			status.SetFromPods()
+
+			config := getConfig()
+			ovs := componentStatus(config, "Ovs")
+			Expect(conditionsv1.IsStatusConditionFalse(ovs.Conditions, conditionsv1.ConditionDegraded)).To(BeTrue())
+			Expect(conditionsv1.IsStatusConditionTrue(ovs.Conditions, conditionsv1.ConditionAvailable)).To(BeTrue())
+			Expect(conditionsv1.IsStatusConditionFalse(config.Status.Conditions, conditionsv1.ConditionDegraded)).To(BeTrue())
+		})
+
+		It("should start a new rollout once the workload is updated", func() {
+			updated := daemonSet("ovs-cni", 3, 1)
+			updated.Generation = 2
+			Expect(c.Update(context.TODO(), updated)).To(Succeed())
+			status.SetFromPods()
+
+			ovs := componentStatus(getConfig(), "Ovs")
+			Expect(conditionsv1.IsStatusConditionTrue(ovs.Conditions, conditionsv1.ConditionProgressing)).To(BeTrue())
+			Expect(conditionsv1.IsStatusConditionFalse(ovs.Conditions, conditionsv1.ConditionDegraded)).To(BeTrue())
+		})
+	})
+
+	Context("when state of a component changes", func() {
+		var recorder *record.FakeRecorder
+
+		BeforeEach(func() {
+			recorder = record.NewFakeRecorder(10)
+			c = newClient(daemonSet("ovs-cni", 3, 3))
+			status = newStatusManager(recorder)
+			status.SetWorkloads([]Workload{workload("Ovs", "DaemonSet", "ovs-cni")})
+			status.SetFromPods()
+		})
+
+		It("should record an event once it becomes ready", func() {
+			Expect(recorder.Events).To(Receive(Equal("Normal ComponentReady Component Ovs is ready")))
+
+			status.SetFromPods()
+			Expect(recorder.Events).NotTo(Receive())
+		})
+
+		It("should record an event on the config and on the workload once it is degraded", func() {
+			Expect(recorder.Events).To(Receive())
+
+			Expect(c.Delete(context.TODO(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})).To(Succeed())
+			status.SetFromPods()
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning ComponentDegraded Component Ovs is degraded: Namespace")))
+			Expect(recorder.Events).NotTo(Receive())
+
+			Expect(c.Create(context.TODO(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})).To(Succeed())
+			Expect(c.Update(context.TODO(), daemonSet("ovs-cni", 3, 1))).To(Succeed())
+			Expect(pods.Add(stuckPod("ovs-cni"))).To(Succeed())
+			status.SetFromPods()
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning ComponentDegraded Component Ovs is degraded: DaemonSet")))
+			Expect(recorder.Events).To(Receive(HavePrefix("Warning ComponentDegraded Component Ovs is degraded: DaemonSet")))
+		})
+	})
+
+	Context("when a component is removed", func() {
+		BeforeEach(func() {
+			c = newClient(daemonSet("ovs-cni", 3, 3), daemonSet("kube-multus-ds", 3, 3))
+			status = newStatusManager(nil)
+			status.SetWorkloads([]Workload{
+				workload("Multus", "DaemonSet", "kube-multus-ds"),
+				workload("Ovs", "DaemonSet", "ovs-cni"),
+			})
+			status.SetFromPods()
+
+			removed := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "ovs-cni"}}
+			Expect(c.Delete(context.TODO(), removed)).To(Succeed())
+			status.SetWorkloads([]Workload{workload("Multus", "DaemonSet", "kube-multus-ds")})
+			status.SetFromPods()
+		})
+
+		It("should not be reported anymore", func() {
+			config := getConfig()
+			Expect(config.Status.Components).To(HaveLen(1))
+			Expect(config.Status.Components[0].Name).To(Equal("Multus"))
+		})
+	})
+
+	Context("when objects are removed while the status is being checked", func() {
+		BeforeEach(func() {
+			c = newClient(daemonSet("ovs-cni", 3, 3), daemonSet("kube-multus-ds", 3, 3))
+			status = newStatusManager(nil)
+			status.SetWorkloads([]Workload{
+				workload("Multus", "DaemonSet", "kube-multus-ds"),
+				workload("Ovs", "DaemonSet", "ovs-cni"),
+			})
+		})
+
+		It("should keep tracking them until they are gone", func() {
+			removed := &unstructured.Unstructured{}
+			removed.SetAPIVersion("apps/v1")
+			removed.SetKind("DaemonSet")
+			removed.SetNamespace(namespace)
+			removed.SetName("ovs-cni")
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for i := 0; i < 10; i++ {
+					status.SetFromPods()
+				}
+			}()
+			status.AddRemovedObjects([]*unstructured.Unstructured{removed})
+			<-done
+
+			status.SetFromPods()
+			Expect(status.IsRemoving()).To(BeTrue())
+
+			Expect(c.Delete(context.TODO(), &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "ovs-cni"}})).To(Succeed())
+			status.SetFromPods()
+			Expect(status.IsRemoving()).To(BeFalse())
+		})
+	})
+})
+
+var _ = Describe("componentStatuses", func() {
+	var originalVersion string
+
+	BeforeEach(func() {
+		originalVersion = operatorVersion
+		operatorVersion = "0.24.0"
+	})
+
+	AfterEach(func() {
+		operatorVersion = originalVersion
+	})
+
+	It("should keep transition times of unchanged conditions and the version until the component is available", func() {
+		transitionTime := metav1.NewTime(metav1.Now().Add(-time.Hour))
+		reported := []opv1.ComponentStatus{
+			{
+				Name:    "Ovs",
+				Version: "0.23.0",
+				Conditions: []conditionsv1.Condition{
+					{Type: conditionsv1.ConditionDegraded, Status: corev1.ConditionFalse, LastTransitionTime: transitionTime},
+				},
+			},
+		}
+
+		progressing := []componentState{{name: "Ovs", progressing: []string{"DaemonSet \"ovs-cni\" is rolling out"}}}
+		statuses := componentStatuses(reported, progressing)
+		Expect(statuses).To(HaveLen(1))
+		Expect(statuses[0].Version).To(Equal("0.23.0"))
+		Expect(conditionsv1.FindStatusCondition(statuses[0].Conditions, conditionsv1.ConditionDegraded).LastTransitionTime).To(Equal(transitionTime))
+
+		available := []componentState{{name: "Ovs"}}
+		statuses = componentStatuses(statuses, available)
+		Expect(statuses[0].Version).To(Equal("0.24.0"))
+		Expect(conditionsv1.IsStatusConditionTrue(statuses[0].Conditions, conditionsv1.ConditionAvailable)).To(BeTrue())
+	})
+})
@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "kubevirt_cnao"
+
+// Phases of reconciliation in which it may fail
+const (
+	PhaseValidate = "validate"
+	PhaseRender   = "render"
+	PhaseApply    = "apply"
+)
+
+// Actions done with applied objects
+const (
+	ActionCreated   = "created"
+	ActionUpdated   = "updated"
+	ActionRecreated = "recreated"
+	ActionUnchanged = "unchanged"
+)
+
+var (
+	reconcileTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconcile_total",
+		Help:      "Number of reconciliations of NetworkAddonsConfig",
+	})
+
+	reconcileDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "reconcile_duration_seconds",
+		Help:      "Duration of reconciliations of NetworkAddonsConfig",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120},
+	})
+
+	reconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconcile_errors_total",
+		Help:      "Number of failed reconciliations of NetworkAddonsConfig by the phase they failed in",
+	}, []string{"phase"})
+
+	objectsApplied = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "objects_applied_total",
+		Help:      "Number of objects applied by the operator by their kind and the action done with them",
+	}, []string{"kind", "action"})
+
+	componentReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "component_ready",
+		Help:      "Whether the deployed component is available (1) or not (0)",
+	}, []string{"component"})
+
+	componentPodsDesired = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "component_pods_desired",
+		Help:      "Number of pods of the deployed component which should be running",
+	}, []string{"component"})
+
+	componentPodsReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "component_pods_ready",
+		Help:      "Number of available pods of the deployed component",
+	}, []string{"component"})
+
+	degraded = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "degraded",
+		Help:      "Whether the NetworkAddonsConfig is Degraded (1) or not (0)",
+	})
+
+	operatorInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "operator_info",
+		Help:      "Version of the running operator and the version which deployed currently running components",
+	}, []string{"version", "observed_version"})
+)
+
+func init() {
+	// Collectors are exposed on the metrics endpoint of the controller manager
+	metrics.Registry.MustRegister(
+		reconcileTotal,
+		reconcileDuration,
+		reconcileErrors,
+		objectsApplied,
+		componentReady,
+		componentPodsDesired,
+		componentPodsReady,
+		degraded,
+		operatorInfo,
+	)
+}
+
+// ComponentReadiness is the observed state of a deployed component
+type ComponentReadiness struct {
+	Name        string
+	Ready       bool
+	DesiredPods int32
+	ReadyPods   int32
+}
+
+// ObserveReconcile counts a reconciliation which started at the given time and records its
+// duration. It is meant to be deferred at the beginning of the reconciliation
+func ObserveReconcile(start time.Time) {
+	reconcileTotal.Inc()
+	reconcileDuration.Observe(time.Since(start).Seconds())
+}
+
+// ReportReconcileError counts a reconciliation which failed in the given phase
+func ReportReconcileError(phase string) {
+	reconcileErrors.WithLabelValues(phase).Inc()
+}
+
+// ReportApplied counts an object of the given kind which was applied with the given action
+func ReportApplied(kind, action string) {
+	objectsApplied.WithLabelValues(kind, action).Inc()
+}
+
+// SetComponents replaces readiness of all deployed components, so removed components are not
+// reported anymore
+func SetComponents(components []ComponentReadiness) {
+	componentReady.Reset()
+	componentPodsDesired.Reset()
+	componentPodsReady.Reset()
+
+	for _, component := range components {
+		ready := 0.0
+		if component.Ready {
+			ready = 1.0
+		}
+		componentReady.WithLabelValues(component.Name).Set(ready)
+		componentPodsDesired.WithLabelValues(component.Name).Set(float64(component.DesiredPods))
+		componentPodsReady.WithLabelValues(component.Name).Set(float64(component.ReadyPods))
+	}
+}
+
+// SetDegraded reports whether the NetworkAddonsConfig is Degraded
+func SetDegraded(isDegraded bool) {
+	if isDegraded {
+		degraded.Set(1)
+	} else {
+		degraded.Set(0)
+	}
+}
+
+// SetOperatorVersion reports the version of the running operator and the version which deployed
+// currently running components
+func SetOperatorVersion(version, observedVersion string) {
+	operatorInfo.Reset()
+	operatorInfo.WithLabelValues(version, observedVersion).Set(1)
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	dto "github.com/prometheus/client_model/go"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// gatheredGauges returns values of gauges of the given family exposed on the metrics endpoint,
+// keyed by value of the given label
+func gatheredGauges(name, label string) map[string]float64 {
+	families, err := metrics.Registry.Gather()
+	Expect(err).NotTo(HaveOccurred())
+
+	values := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			values[labelValue(metric, label)] = metric.GetGauge().GetValue()
+		}
+	}
+	return values
+}
+
+func labelValue(metric *dto.Metric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+var _ = Describe("Testing metrics", func() {
+	Describe("SetComponents", func() {
+		It("should report readiness and pods of deployed components only", func() {
+			SetComponents([]ComponentReadiness{
+				{Name: "Multus", Ready: true, DesiredPods: 3, ReadyPods: 3},
+				{Name: "Ovs", Ready: false, DesiredPods: 3, ReadyPods: 1},
+			})
+			SetComponents([]ComponentReadiness{
+				{Name: "Ovs", Ready: true, DesiredPods: 3, ReadyPods: 3},
+			})
+
+			Expect(gatheredGauges("kubevirt_cnao_component_ready", "component")).To(Equal(map[string]float64{"Ovs": 1}))
+			Expect(gatheredGauges("kubevirt_cnao_component_pods_ready", "component")).To(Equal(map[string]float64{"Ovs": 3}))
+		})
+	})
+
+	Describe("SetOperatorVersion", func() {
+		It("should report only the current versions", func() {
+			SetOperatorVersion("0.24.0", "0.23.0")
+			SetOperatorVersion("0.24.0", "0.24.0")
+
+			Expect(gatheredGauges("kubevirt_cnao_operator_info", "observed_version")).To(Equal(map[string]float64{"0.24.0": 1}))
+		})
+	})
+})
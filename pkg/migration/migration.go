@@ -0,0 +1,220 @@
+package migration
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
+)
+
+// Migration is a one-time change of objects deployed by an older version of the operator, which
+// cannot be achieved just by applying rendered objects. Each migration is run at most once per
+// cluster, finished migrations are recorded by their ID.
+type Migration struct {
+	// Version of the operator which introduced the migration
+	Version string
+	// Name describing the migration, it has to be unique within the Version
+	Name string
+	// Delete lists obsolete objects which are not rendered anymore
+	Delete []apply.InventoryItem
+	// Replacements lists rendered objects taking over from those in Delete. When set, the
+	// migration waits until the replacements are applied and rolled out, so the obsolete objects
+	// are not removed before their successors are running
+	Replacements []apply.InventoryItem
+	// Recreate lists objects which are removed, so they are created from templates again right
+	// away, e.g. because a field which cannot be updated was changed
+	Recreate []apply.InventoryItem
+	// Transform performs changes which cannot be declared by the lists above
+	Transform func(ctx context.Context, client k8sclient.Client) error
+}
+
+// ID identifies the migration in the record of finished migrations
+func (m Migration) ID() string {
+	return m.Version + "/" + m.Name
+}
+
+// IDs lists identifiers of given migrations
+func IDs(migrations []Migration) []string {
+	ids := []string{}
+	for _, m := range migrations {
+		ids = append(ids, m.ID())
+	}
+	return ids
+}
+
+// Pending returns migrations which were not finished yet, ordered by the version of the operator
+// which introduced them. Migrations of the same version keep their order.
+func Pending(migrations []Migration, finished []string) ([]Migration, error) {
+	done := map[string]bool{}
+	for _, id := range finished {
+		done[id] = true
+	}
+
+	pending := []Migration{}
+	versions := map[string]semver.Version{}
+	for _, m := range migrations {
+		version, err := semver.Make(m.Version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "migration %s has invalid version", m.ID())
+		}
+		versions[m.Version] = version
+		if !done[m.ID()] {
+			pending = append(pending, m)
+		}
+	}
+
+	sort.SliceStable(pending, func(a, b int) bool {
+		return versions[pending[a].Version].LT(versions[pending[b].Version])
+	})
+
+	return pending, nil
+}
+
+// Run performs given migrations in order. It returns IDs of those which were finished, even if
+// one of them failed. Migrations following the failed one are not run. The same goes for a
+// migration waiting for its replacements to be rolled out, it is not reported as finished and
+// has to be run again later.
+func Run(ctx context.Context, client k8sclient.Client, migrations []Migration) ([]string, error) {
+	finished := []string{}
+
+	for _, m := range migrations {
+		ready, err := replacementsReady(ctx, client, m)
+		if err != nil {
+			return finished, errors.Wrapf(err, "migration %s failed to check replacements", m.ID())
+		}
+		if !ready {
+			log.Printf("migration %s is waiting for replacements to be rolled out", m.ID())
+			return finished, nil
+		}
+
+		log.Printf("running migration %s", m.ID())
+
+		for _, item := range m.Delete {
+			if err := remove(ctx, client, item.Object()); err != nil {
+				return finished, errors.Wrapf(err, "migration %s failed to delete obsolete object", m.ID())
+			}
+		}
+
+		for _, item := range m.Recreate {
+			if err := remove(ctx, client, item.Object()); err != nil {
+				return finished, errors.Wrapf(err, "migration %s failed to delete object to be recreated", m.ID())
+			}
+		}
+
+		if m.Transform != nil {
+			if err := m.Transform(ctx, client); err != nil {
+				return finished, errors.Wrapf(err, "migration %s failed", m.ID())
+			}
+		}
+
+		finished = append(finished, m.ID())
+	}
+
+	return finished, nil
+}
+
+// replacementsReady checks whether all replacements of the migration exist and, in case of
+// workloads, whether they are rolled out, i.e. all their pods are updated and available. There is
+// nothing to wait for when the obsolete objects are gone already, e.g. because their component
+// was removed from the configuration and its replacements are never going to be applied.
+func replacementsReady(ctx context.Context, client k8sclient.Client, m Migration) (bool, error) {
+	if len(m.Replacements) == 0 {
+		return true, nil
+	}
+
+	obsoleteFound := false
+	for _, item := range m.Delete {
+		current, err := get(ctx, client, item.Object())
+		if err != nil {
+			return false, err
+		}
+		if current != nil {
+			obsoleteFound = true
+			break
+		}
+	}
+	if !obsoleteFound {
+		return true, nil
+	}
+
+	for _, item := range m.Replacements {
+		current, err := get(ctx, client, item.Object())
+		if err != nil {
+			return false, err
+		}
+		if current == nil || !isRolledOut(current) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// get reads the current state of the object from the apiserver. It returns nil if the object, or
+// its kind, does not exist.
+func get(ctx context.Context, client k8sclient.Client, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(obj.GroupVersionKind())
+	err := client.Get(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, current)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not retrieve (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+	}
+	return current, nil
+}
+
+// isRolledOut checks status of a DaemonSet or a Deployment read from the apiserver. Objects of
+// other kinds are rolled out once they exist.
+func isRolledOut(obj *unstructured.Unstructured) bool {
+	status := func(field string) int64 {
+		value, _, _ := unstructured.NestedInt64(obj.Object, "status", field)
+		return value
+	}
+	if status("observedGeneration") < obj.GetGeneration() {
+		return false
+	}
+
+	switch obj.GetKind() {
+	case "DaemonSet":
+		desired := status("desiredNumberScheduled")
+		return status("updatedNumberScheduled") == desired && status("numberAvailable") == desired
+	case "Deployment":
+		desired, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		if !found {
+			desired = 1
+		}
+		return status("updatedReplicas") == desired && status("availableReplicas") == desired
+	}
+	return true
+}
+
+// remove deletes the object together with its dependants. Objects which do not exist, including
+// those of kinds unknown to the cluster, are skipped.
+func remove(ctx context.Context, client k8sclient.Client, obj *unstructured.Unstructured) error {
+	existing, err := get(ctx, client, obj)
+	if err != nil || existing == nil {
+		return err
+	}
+
+	err = client.Delete(ctx, existing, k8sclient.PropagationPolicy(metav1.DeletePropagationBackground))
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "could not delete (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+	}
+	log.Printf("deleted (%s) %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+
+	return nil
+}
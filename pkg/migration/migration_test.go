@@ -0,0 +1,179 @@
+package migration_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/migration"
+)
+
+var _ = Describe("Migration", func() {
+	Describe("Pending", func() {
+		migrations := []migration.Migration{
+			{Version: "0.24.0", Name: "b"},
+			{Version: "0.16.0", Name: "a"},
+			{Version: "0.24.0", Name: "a"},
+			{Version: "0.9.0", Name: "a"},
+		}
+
+		It("should order unfinished migrations by version", func() {
+			pending, err := migration.Pending(migrations, []string{"0.16.0/a"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(migration.IDs(pending)).To(Equal([]string{"0.9.0/a", "0.24.0/b", "0.24.0/a"}))
+		})
+
+		It("should fail on invalid version", func() {
+			_, err := migration.Pending([]migration.Migration{{Version: "master", Name: "a"}}, nil)
+			Expect(err).To(MatchError(ContainSubstring("migration master/a has invalid version")))
+		})
+	})
+
+	Describe("Run", func() {
+		var client k8sclient.Client
+
+		daemonSet := func(name string) *appsv1.DaemonSet {
+			return &appsv1.DaemonSet{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "cluster-network-addons"},
+			}
+		}
+		daemonSetItem := func(name string) apply.InventoryItem {
+			return apply.InventoryItem{Group: "apps", Version: "v1", Kind: "DaemonSet", Namespace: "cluster-network-addons", Name: name}
+		}
+		exists := func(name string) bool {
+			err := client.Get(context.TODO(), types.NamespacedName{Namespace: "cluster-network-addons", Name: name}, &appsv1.DaemonSet{})
+			if apierrors.IsNotFound(err) {
+				return false
+			}
+			Expect(err).NotTo(HaveOccurred())
+			return true
+		}
+
+		BeforeEach(func() {
+			client = fake.NewFakeClient(daemonSet("obsolete"), daemonSet("immutable"), daemonSet("kept"))
+		})
+
+		Context("when migrations declare objects to delete and recreate", func() {
+			transformed := false
+			migrations := []migration.Migration{
+				{Version: "0.1.0", Name: "delete", Delete: []apply.InventoryItem{daemonSetItem("obsolete"), daemonSetItem("missing")}},
+				{Version: "0.2.0", Name: "recreate", Recreate: []apply.InventoryItem{daemonSetItem("immutable")}},
+				{Version: "0.2.0", Name: "transform", Transform: func(ctx context.Context, client k8sclient.Client) error {
+					transformed = true
+					return nil
+				}},
+			}
+
+			It("should run all of them and report them as finished", func() {
+				finished, err := migration.Run(context.TODO(), client, migrations)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(finished).To(Equal([]string{"0.1.0/delete", "0.2.0/recreate", "0.2.0/transform"}))
+				Expect(exists("obsolete")).To(BeFalse())
+				Expect(exists("immutable")).To(BeFalse())
+				Expect(exists("kept")).To(BeTrue())
+				Expect(transformed).To(BeTrue())
+			})
+		})
+
+		Context("when a migration fails", func() {
+			migrations := []migration.Migration{
+				{Version: "0.1.0", Name: "delete", Delete: []apply.InventoryItem{daemonSetItem("obsolete")}},
+				{Version: "0.2.0", Name: "failing", Transform: func(ctx context.Context, client k8sclient.Client) error {
+					return errors.New("boom")
+				}},
+				{Version: "0.3.0", Name: "delete", Delete: []apply.InventoryItem{daemonSetItem("kept")}},
+			}
+
+			It("should report those finished before it and stop", func() {
+				finished, err := migration.Run(context.TODO(), client, migrations)
+				Expect(err).To(MatchError("migration 0.2.0/failing failed: boom"))
+				Expect(finished).To(Equal([]string{"0.1.0/delete"}))
+				Expect(exists("kept")).To(BeTrue())
+			})
+		})
+
+		Context("when a migration declares replacements of obsolete objects", func() {
+			migrations := []migration.Migration{
+				{Version: "0.1.0", Name: "replace", Delete: []apply.InventoryItem{daemonSetItem("obsolete")}, Replacements: []apply.InventoryItem{daemonSetItem("replacement")}},
+				{Version: "0.2.0", Name: "delete", Delete: []apply.InventoryItem{daemonSetItem("kept")}},
+			}
+			rolledOut := func(ds *appsv1.DaemonSet, updated int32) *appsv1.DaemonSet {
+				ds.Generation = 2
+				ds.Status = appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: updated, NumberAvailable: updated}
+				return ds
+			}
+
+			Context("and the replacement is not applied yet", func() {
+				It("should wait and keep the obsolete object", func() {
+					finished, err := migration.Run(context.TODO(), client, migrations)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(finished).To(BeEmpty())
+					Expect(exists("obsolete")).To(BeTrue())
+					Expect(exists("kept")).To(BeTrue())
+				})
+			})
+
+			Context("and the replacement is not rolled out yet", func() {
+				BeforeEach(func() {
+					Expect(client.Create(context.TODO(), rolledOut(daemonSet("replacement"), 1))).To(Succeed())
+				})
+
+				It("should wait and keep the obsolete object", func() {
+					finished, err := migration.Run(context.TODO(), client, migrations)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(finished).To(BeEmpty())
+					Expect(exists("obsolete")).To(BeTrue())
+				})
+			})
+
+			Context("and the replacement is rolled out", func() {
+				BeforeEach(func() {
+					Expect(client.Create(context.TODO(), rolledOut(daemonSet("replacement"), 3))).To(Succeed())
+				})
+
+				It("should delete the obsolete object", func() {
+					finished, err := migration.Run(context.TODO(), client, migrations)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(finished).To(Equal([]string{"0.1.0/replace", "0.2.0/delete"}))
+					Expect(exists("obsolete")).To(BeFalse())
+					Expect(exists("replacement")).To(BeTrue())
+				})
+			})
+
+			Context("and the obsolete object is gone already", func() {
+				BeforeEach(func() {
+					client = fake.NewFakeClient(daemonSet("kept"))
+				})
+
+				It("should not wait for the replacement", func() {
+					finished, err := migration.Run(context.TODO(), client, migrations)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(finished).To(Equal([]string{"0.1.0/replace", "0.2.0/delete"}))
+				})
+			})
+		})
+
+		Context("when an object to delete does not exist", func() {
+			migrations := []migration.Migration{
+				{Version: "0.1.0", Name: "delete", Delete: []apply.InventoryItem{{Version: "v1", Kind: "ConfigMap", Namespace: "foo", Name: "bar"}}},
+			}
+
+			It("should pass", func() {
+				finished, err := migration.Run(context.TODO(), client, migrations)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(finished).To(HaveLen(1))
+			})
+		})
+	})
+})
@@ -7,3 +7,28 @@ const OPERATOR_CONFIG = "cluster"
 // APPLIED_PREFIX is the prefix applied to the config maps
 // where we store previously applied configuration
 const APPLIED_PREFIX = "cluster-networks-addons-operator-applied-"
+
+// DRY_RUN_REPORT_PREFIX is the prefix of the config map where we report changes
+// planned in dry run mode
+const DRY_RUN_REPORT_PREFIX = "cluster-network-addons-operator-dry-run-"
+
+// OPERATOR_COMPONENT is the name of the operator reported as the source of its events
+const OPERATOR_COMPONENT = "cluster-network-addons-operator"
+
+// WEBHOOK_SERVICE is the name of the service exposing operator's webhooks
+const WEBHOOK_SERVICE = "cluster-network-addons-operator-webhook"
+
+// WEBHOOK_CA_SECRET is the name of the Secret keeping the CA of operator's webhooks, so it
+// survives restarts of the operator
+const WEBHOOK_CA_SECRET = "cluster-network-addons-operator-webhook-ca"
+
+// WEBHOOK_PORT is the port on which operator's webhooks are served
+const WEBHOOK_PORT = 8443
+
+// VALIDATING_WEBHOOK_CONFIGURATION is the name of the ValidatingWebhookConfiguration
+// registering operator's validating webhooks
+const VALIDATING_WEBHOOK_CONFIGURATION = "cluster-network-addons-operator-validator"
+
+// MUTATING_WEBHOOK_CONFIGURATION is the name of the MutatingWebhookConfiguration
+// registering operator's mutating webhooks
+const MUTATING_WEBHOOK_CONFIGURATION = "cluster-network-addons-operator-mutator"
@@ -0,0 +1,36 @@
+package network
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ApplyStage is a group of objects which are applied together. The next stage is applied only
+// once all objects of the previous one are ready, see apply.NotReady
+type ApplyStage struct {
+	Name    string
+	Objects []*unstructured.Unstructured
+}
+
+// Stages of the deployment and kinds of objects applied in them, all remaining kinds, including
+// custom resources, are applied in the Configuration stage:
+//   - Namespaces and CRDs go first, everything else may live in them or be their instance.
+//   - RBAC is applied before workloads, so pods never start without their permissions.
+//   - Webhooks go last, so API requests are not sent to webhook servers which are not running yet.
+var applyStageKinds = []stageKinds{
+	{"Namespaces", []string{"Namespace"}},
+	{"CRDs", []string{"CustomResourceDefinition"}},
+	{"RBAC", []string{"ServiceAccount", "Role", "RoleBinding", "ClusterRole", "ClusterRoleBinding", "SecurityContextConstraints"}},
+	{"Configuration", nil},
+	{"Workloads", []string{"DaemonSet", "Deployment", "PodDisruptionBudget"}},
+	{"Webhooks", []string{"MutatingWebhookConfiguration", "ValidatingWebhookConfiguration"}},
+}
+
+// ApplyStages sorts rendered objects into stages in which they have to be applied. Objects keep
+// their rendered order within each stage, so the result is deterministic
+func ApplyStages(objs []*unstructured.Unstructured) []ApplyStage {
+	stages := []ApplyStage{}
+	for i, stageObjs := range sortIntoStages(applyStageKinds, objs) {
+		stages = append(stages, ApplyStage{Name: applyStageKinds[i].name, Objects: stageObjs})
+	}
+	return stages
+}
@@ -0,0 +1,100 @@
+package network
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+var _ = Describe("Testing apply stages", func() {
+	Describe("ApplyStages", func() {
+		clusterInfo := &ClusterInfo{SCCAvailable: true, OpenShift4: false}
+		conf := &opv1.NetworkAddonsConfigSpec{
+			ImagePullPolicy: v1.PullAlways,
+			Multus:          &opv1.Multus{},
+			LinuxBridge:     &opv1.LinuxBridge{},
+			Ovs:             &opv1.Ovs{},
+			KubeMacPool:     &opv1.KubeMacPool{RangeStart: "02:00:00:00:00:00", RangeEnd: "02:FF:FF:FF:FF:FF"},
+			NMState:         &opv1.NMState{},
+		}
+
+		var objs []*unstructured.Unstructured
+
+		BeforeEach(func() {
+			os.Setenv("OPERAND_NAMESPACE", "cluster-network-addons")
+			var err error
+			objs, err = Render(conf, "../../data", nil, clusterInfo)
+			Expect(err).NotTo(HaveOccurred())
+			mutating := []admissionregistrationv1beta1.MutatingWebhookConfiguration{webhookConfiguration("kubemacpool", "cluster-network-addons", "kubemacpool-service")}
+			objs = append(objs, RuntimeObjects(objs, mutating)...)
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("OPERAND_NAMESPACE")
+		})
+
+		kindsOf := func(stage ApplyStage) map[string]bool {
+			kinds := map[string]bool{}
+			for _, obj := range stage.Objects {
+				kinds[obj.GetKind()] = true
+			}
+			return kinds
+		}
+
+		It("should apply namespaces and CRDs, RBAC, configuration, workloads and finally webhooks", func() {
+			stages := ApplyStages(objs)
+
+			Expect(stages).To(HaveLen(6))
+			Expect(stages[0].Name).To(Equal("Namespaces"))
+			Expect(kindsOf(stages[0])).To(Equal(map[string]bool{"Namespace": true}))
+			Expect(stages[1].Name).To(Equal("CRDs"))
+			Expect(kindsOf(stages[1])).To(Equal(map[string]bool{"CustomResourceDefinition": true}))
+			Expect(stages[2].Name).To(Equal("RBAC"))
+			Expect(kindsOf(stages[2])).To(Equal(map[string]bool{
+				"ServiceAccount":             true,
+				"Role":                       true,
+				"RoleBinding":                true,
+				"ClusterRole":                true,
+				"ClusterRoleBinding":         true,
+				"SecurityContextConstraints": true,
+			}))
+			Expect(stages[3].Name).To(Equal("Configuration"))
+			Expect(kindsOf(stages[3])).To(HaveKey("ConfigMap"))
+			Expect(kindsOf(stages[3])).To(HaveKey("Service"))
+			Expect(stages[4].Name).To(Equal("Workloads"))
+			Expect(kindsOf(stages[4])).To(Equal(map[string]bool{"DaemonSet": true, "Deployment": true, "PodDisruptionBudget": true}))
+			Expect(stages[5].Name).To(Equal("Webhooks"))
+			Expect(kindsOf(stages[5])).To(Equal(map[string]bool{"MutatingWebhookConfiguration": true}))
+
+			total := 0
+			for _, stage := range stages {
+				total += len(stage.Objects)
+			}
+			Expect(total).To(Equal(len(objs)))
+		})
+
+		It("should keep the rendered order within each stage", func() {
+			position := map[*unstructured.Unstructured]int{}
+			for i, obj := range objs {
+				position[obj] = i
+			}
+
+			for _, stage := range ApplyStages(objs) {
+				for i := 1; i < len(stage.Objects); i++ {
+					Expect(position[stage.Objects[i-1]]).To(BeNumerically("<", position[stage.Objects[i]]), "order of stage %s was changed", stage.Name)
+				}
+			}
+		})
+
+		It("should be deterministic", func() {
+			Expect(ApplyStages(objs)).To(Equal(ApplyStages(objs)))
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package network
 
 type ClusterInfo struct {
-	SCCAvailable bool
-	OpenShift4   bool
+	SCCAvailable        bool
+	OpenShift4          bool
+	MonitoringAvailable bool
 }
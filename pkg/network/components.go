@@ -0,0 +1,51 @@
+package network
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+// Names of components, as they are reported in logs and in the status
+const (
+	MultusComponent      = "Multus"
+	LinuxBridgeComponent = "LinuxBridge"
+	KubeMacPoolComponent = "KubeMacPool"
+	NMStateComponent     = "NMState"
+	OvsComponent         = "Ovs"
+	MonitoringComponent  = "Monitoring"
+)
+
+// ComponentLabel marks DaemonSets and Deployments, together with their pods, with the name of the
+// component they belong to, so their state can be reported per component. Other objects are not
+// labeled, some of them, such as namespaces, are shared by several components
+var ComponentLabel = opv1.SchemeGroupVersion.Group + "/component"
+
+// ComponentOf returns the name of the component the workload belongs to, or an empty string if
+// the object is not labeled
+func ComponentOf(obj *unstructured.Unstructured) string {
+	return obj.GetLabels()[ComponentLabel]
+}
+
+func labelWorkloads(objs []*unstructured.Unstructured, component string) {
+	for _, obj := range objs {
+		if obj.GetKind() != "DaemonSet" && obj.GetKind() != "Deployment" {
+			continue
+		}
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[ComponentLabel] = component
+		obj.SetLabels(labels)
+
+		// Pods are labeled too, so only pods of components are watched by the operator. The
+		// label is not added to selectors, those cannot be changed on deployed workloads
+		podLabels, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "template", "metadata", "labels")
+		if podLabels == nil {
+			podLabels = map[string]string{}
+		}
+		podLabels[ComponentLabel] = component
+		unstructured.SetNestedStringMap(obj.Object, podLabels, "spec", "template", "metadata", "labels")
+	}
+}
@@ -0,0 +1,50 @@
+package network
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+var _ = Describe("Testing components", func() {
+	Describe("Render", func() {
+		clusterInfo := &ClusterInfo{SCCAvailable: true, OpenShift4: false}
+		conf := &opv1.NetworkAddonsConfigSpec{
+			ImagePullPolicy: v1.PullAlways,
+			Multus:          &opv1.Multus{},
+			LinuxBridge:     &opv1.LinuxBridge{},
+			Ovs:             &opv1.Ovs{},
+			KubeMacPool:     &opv1.KubeMacPool{RangeStart: "02:00:00:00:00:00", RangeEnd: "02:FF:FF:FF:FF:FF"},
+			NMState:         &opv1.NMState{},
+		}
+
+		It("should label workloads and their pods with their component and leave other objects unlabeled", func() {
+			objs, err := Render(conf, "../../data", nil, clusterInfo)
+			Expect(err).NotTo(HaveOccurred())
+
+			components := map[string]string{}
+			for _, obj := range objs {
+				if obj.GetKind() == "DaemonSet" || obj.GetKind() == "Deployment" {
+					components[obj.GetName()] = ComponentOf(obj)
+					podLabels, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "template", "metadata", "labels")
+					Expect(podLabels).To(HaveKeyWithValue(ComponentLabel, ComponentOf(obj)), "pods of %s %s should be labeled", obj.GetKind(), obj.GetName())
+				} else {
+					Expect(obj.GetLabels()).NotTo(HaveKey(ComponentLabel), "%s %s should not be labeled", obj.GetKind(), obj.GetName())
+				}
+			}
+
+			Expect(components).To(Equal(map[string]string{
+				"kube-multus-ds":                     MultusComponent,
+				"bridge-marker":                      LinuxBridgeComponent,
+				"kube-cni-linux-bridge-plugin":       LinuxBridgeComponent,
+				"kubemacpool-mac-controller-manager": KubeMacPoolComponent,
+				"nmstate-handler":                    NMStateComponent,
+				"ovs-cni":                            OvsComponent,
+			}))
+		})
+	})
+})
@@ -1,19 +1,15 @@
 package network
 
 import (
-	"context"
-
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
-	opv1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
 )
 
 const defaultImagePullPolicy = v1.PullIfNotPresent
 
-func validateImagePullPolicy(conf *opv1alpha1.NetworkAddonsConfigSpec) []error {
+func validateImagePullPolicy(conf *opv1.NetworkAddonsConfigSpec) []error {
 	if conf.ImagePullPolicy == "" {
 		return []error{}
 	}
@@ -25,7 +21,7 @@ func validateImagePullPolicy(conf *opv1alpha1.NetworkAddonsConfigSpec) []error {
 	return []error{}
 }
 
-func fillDefaultsImagePullPolicy(conf, previous *opv1alpha1.NetworkAddonsConfigSpec) []error {
+func fillDefaultsImagePullPolicy(conf, previous *opv1.NetworkAddonsConfigSpec) []error {
 	if conf.ImagePullPolicy == "" {
 		if previous != nil && previous.ImagePullPolicy != "" {
 			conf.ImagePullPolicy = previous.ImagePullPolicy
@@ -37,18 +33,13 @@ func fillDefaultsImagePullPolicy(conf, previous *opv1alpha1.NetworkAddonsConfigS
 	return []error{}
 }
 
-func changeSafeImagePullPolicy(prev, next *opv1alpha1.NetworkAddonsConfigSpec) []error {
+func changeSafeImagePullPolicy(prev, next *opv1.NetworkAddonsConfigSpec) []error {
 	if prev.ImagePullPolicy != "" && prev.ImagePullPolicy != next.ImagePullPolicy {
 		return []error{errors.Errorf("cannot modify ImagePullPolicy configuration once components were deployed")}
 	}
 	return []error{}
 }
 
-// Currently not implemented, since there are no obsolete objects under this module
-func CleanUpImagePullPolicy(ctx context.Context, client k8sclient.Client, objs []*unstructured.Unstructured) []error {
-	return nil
-}
-
 // Verify if the value is a valid PullPolicy
 func verifyPullPolicyType(imagePullPolicy v1.PullPolicy) bool {
 	switch imagePullPolicy {
@@ -0,0 +1,57 @@
+package network
+
+import (
+	"os"
+
+	"github.com/docker/distribution/reference"
+	"github.com/pkg/errors"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+// image returns image configured for a component, or the default image of the operator which is
+// passed in the given environment variable
+func image(configured string, defaultEnv string) string {
+	if configured != "" {
+		return configured
+	}
+	return os.Getenv(defaultEnv)
+}
+
+func validateImages(conf *opv1.NetworkAddonsConfigSpec) []error {
+	errs := []error{}
+
+	if conf.Multus != nil {
+		errs = append(errs, validateImage("multus.image", conf.Multus.Image)...)
+	}
+	if conf.LinuxBridge != nil {
+		errs = append(errs, validateImage("linuxBridge.image", conf.LinuxBridge.Image)...)
+		errs = append(errs, validateImage("linuxBridge.markerImage", conf.LinuxBridge.MarkerImage)...)
+	}
+	if conf.Ovs != nil {
+		errs = append(errs, validateImage("ovs.image", conf.Ovs.Image)...)
+		errs = append(errs, validateImage("ovs.markerImage", conf.Ovs.MarkerImage)...)
+	}
+	if conf.KubeMacPool != nil {
+		errs = append(errs, validateImage("kubeMacPool.image", conf.KubeMacPool.Image)...)
+	}
+	if conf.NMState != nil {
+		errs = append(errs, validateImage("nmstate.image", conf.NMState.Image)...)
+	}
+
+	return errs
+}
+
+// validateImage checks that the image is a valid reference, e.g. quay.io/kubevirt/ovs-cni-plugin:v0.9.0.
+// The image may be pinned by a digest, in which case the digest is verified too
+func validateImage(path string, image string) []error {
+	if image == "" {
+		return []error{}
+	}
+
+	if _, err := reference.ParseNormalizedNamed(image); err != nil {
+		return []error{errors.Errorf("%s '%s' is not a valid image reference: %v", path, image, err)}
+	}
+
+	return []error{}
+}
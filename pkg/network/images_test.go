@@ -0,0 +1,103 @@
+package network
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+var _ = Describe("Testing images", func() {
+	DescribeTable("validateImage",
+		func(image string, valid bool) {
+			errs := validateImage("multus.image", image)
+			if valid {
+				Expect(errs).To(BeEmpty())
+			} else {
+				Expect(errs).To(ConsistOf(MatchError(ContainSubstring("multus.image '" + image + "' is not a valid image reference"))))
+			}
+		},
+		Entry("should accept empty image", "", true),
+		Entry("should accept tagged image", "quay.io/kubevirt/cluster-network-addon-multus:v3.2.0", true),
+		Entry("should accept image without registry", "kubevirt/ovs-cni-plugin", true),
+		Entry("should accept image on registry with port", "registry.local:5000/kubevirt/ovs-cni-plugin:latest", true),
+		Entry("should accept image pinned by digest", "quay.io/kubevirt/kubemacpool@sha256:0d7e8ee3bb1a4a6cb1e1bd3a2ae06d3b3e3f5df2a07a0e8ab9e3f4d8cb1e0a5f", true),
+		Entry("should accept tagged image pinned by digest", "quay.io/kubevirt/kubemacpool:v0.8.0@sha256:0d7e8ee3bb1a4a6cb1e1bd3a2ae06d3b3e3f5df2a07a0e8ab9e3f4d8cb1e0a5f", true),
+		Entry("should reject image with upper case name", "quay.io/KubeVirt/kubemacpool:v0.8.0", false),
+		Entry("should reject image with invalid tag", "quay.io/kubevirt/kubemacpool:v0.8.0:latest", false),
+		Entry("should reject image with invalid digest", "quay.io/kubevirt/kubemacpool@sha256:foo", false),
+		Entry("should reject image with whitespace", "quay.io/kubevirt/kubemacpool v0.8.0", false),
+	)
+
+	Describe("validateImages", func() {
+		Context("when marker image is invalid", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{
+				Ovs: &opv1.Ovs{Image: "quay.io/kubevirt/ovs-cni-plugin:v0.9.0", MarkerImage: "ovs-cni-marker:"},
+			}
+
+			It("should report path of the invalid field", func() {
+				Expect(validateImages(conf)).To(ConsistOf(MatchError(ContainSubstring("ovs.markerImage 'ovs-cni-marker:' is not a valid image reference"))))
+			})
+		})
+	})
+
+	Describe("IsChangeSafe", func() {
+		Context("when only image of a deployed component is changed", func() {
+			prev := &opv1.NetworkAddonsConfigSpec{Multus: &opv1.Multus{}}
+			next := &opv1.NetworkAddonsConfigSpec{Multus: &opv1.Multus{Image: "quay.io/kubevirt/cluster-network-addon-multus:v3.4.0"}}
+
+			It("should pass the check", func() {
+				Expect(IsChangeSafe(prev, next)).To(Succeed())
+			})
+		})
+	})
+
+	Describe("Render", func() {
+		clusterInfo := &ClusterInfo{SCCAvailable: true, OpenShift4: false}
+
+		images := func(objs []*unstructured.Unstructured) map[string]string {
+			images := map[string]string{}
+			for _, obj := range objs {
+				containers, _, err := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+				Expect(err).NotTo(HaveOccurred())
+				for _, container := range containers {
+					container := container.(map[string]interface{})
+					images[container["name"].(string)] = container["image"].(string)
+				}
+			}
+			return images
+		}
+
+		BeforeEach(func() {
+			os.Setenv("LINUX_BRIDGE_IMAGE", "quay.io/kubevirt/cni-default-plugins:v0.8.1")
+			os.Setenv("LINUX_BRIDGE_MARKER_IMAGE", "quay.io/kubevirt/bridge-marker:0.2.0")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("LINUX_BRIDGE_IMAGE")
+			os.Unsetenv("LINUX_BRIDGE_MARKER_IMAGE")
+		})
+
+		Context("when image of a component is configured", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{
+				ImagePullPolicy: v1.PullAlways,
+				LinuxBridge:     &opv1.LinuxBridge{MarkerImage: "registry.local/bridge-marker:devel"},
+			}
+
+			It("should take precedence over the default image", func() {
+				objs, err := Render(conf, "../../data", nil, clusterInfo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(images(objs)).To(Equal(map[string]string{
+					"cni-plugins":   "quay.io/kubevirt/cni-default-plugins:v0.8.1",
+					"bridge-marker": "registry.local/bridge-marker:devel",
+				}))
+			})
+		})
+	})
+})
@@ -1,24 +1,21 @@
 package network
 
 import (
-	"context"
 	"crypto/rand"
 	"fmt"
 	"net"
 	"os"
-	"path/filepath"
 	"reflect"
 
 	"github.com/kubevirt/cluster-network-addons-operator/pkg/render"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
-	opv1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
 )
 
 // ValidateMultus validates the combination of DisableMultiNetwork and AddtionalNetworks
-func validateKubeMacPool(conf *opv1alpha1.NetworkAddonsConfigSpec) []error {
+func validateKubeMacPool(conf *opv1.NetworkAddonsConfigSpec) []error {
 	if conf.KubeMacPool == nil {
 		return []error{}
 	}
@@ -60,7 +57,7 @@ func validateKubeMacPool(conf *opv1alpha1.NetworkAddonsConfigSpec) []error {
 	return []error{}
 }
 
-func fillDefaultsKubeMacPool(conf, previous *opv1alpha1.NetworkAddonsConfigSpec) []error {
+func fillDefaultsKubeMacPool(conf, previous *opv1.NetworkAddonsConfigSpec) []error {
 	if conf.KubeMacPool == nil {
 		return []error{}
 	}
@@ -68,7 +65,8 @@ func fillDefaultsKubeMacPool(conf, previous *opv1alpha1.NetworkAddonsConfigSpec)
 	// If user hasn't explicitly requested a range, we try to reuse previously applied range
 	if conf.KubeMacPool.RangeStart == "" || conf.KubeMacPool.RangeEnd == "" {
 		if previous != nil && previous.KubeMacPool != nil {
-			conf.KubeMacPool = previous.KubeMacPool
+			conf.KubeMacPool.RangeStart = previous.KubeMacPool.RangeStart
+			conf.KubeMacPool.RangeEnd = previous.KubeMacPool.RangeEnd
 			return []error{}
 		}
 
@@ -88,20 +86,15 @@ func fillDefaultsKubeMacPool(conf, previous *opv1alpha1.NetworkAddonsConfigSpec)
 	return []error{}
 }
 
-func changeSafeKubeMacPool(prev, next *opv1alpha1.NetworkAddonsConfigSpec) []error {
-	if prev.KubeMacPool != nil && !reflect.DeepEqual(prev.KubeMacPool, next.KubeMacPool) {
+func changeSafeKubeMacPool(prev, next *opv1.NetworkAddonsConfigSpec) []error {
+	if prev.KubeMacPool != nil && next.KubeMacPool != nil && !reflect.DeepEqual(prev.KubeMacPool, next.KubeMacPool) {
 		return []error{errors.Errorf("cannot modify KubeMacPool configuration once it is deployed")}
 	}
 	return []error{}
 }
 
-// Currently not implemented, since there are no obsolete objects under this module
-func CleanUpKubeMacPool(ctx context.Context, client k8sclient.Client, objs []*unstructured.Unstructured) []error {
-	return nil
-}
-
 // renderLinuxBridge generates the manifests of Linux Bridge
-func renderKubeMacPool(conf *opv1alpha1.NetworkAddonsConfigSpec, manifestDir string) ([]*unstructured.Unstructured, error) {
+func renderKubeMacPool(conf *opv1.NetworkAddonsConfigSpec, manifestDir string) ([]*unstructured.Unstructured, error) {
 	if conf.KubeMacPool == nil {
 		return nil, nil
 	}
@@ -109,16 +102,25 @@ func renderKubeMacPool(conf *opv1alpha1.NetworkAddonsConfigSpec, manifestDir str
 	// render the manifests on disk
 	data := render.MakeRenderData()
 	data.Data["Namespace"] = os.Getenv("OPERAND_NAMESPACE")
-	data.Data["KubeMacPoolImage"] = os.Getenv("KUBEMACPOOL_IMAGE")
+	data.Data["KubeMacPoolImage"] = image(conf.KubeMacPool.Image, "KUBEMACPOOL_IMAGE")
 	data.Data["ImagePullPolicy"] = conf.ImagePullPolicy
 	data.Data["RangeStart"] = conf.KubeMacPool.RangeStart
 	data.Data["RangeEnd"] = conf.KubeMacPool.RangeEnd
+	placement, err := componentPlacement(defaultKubeMacPoolPlacement(), conf.Placement, conf.KubeMacPool.Placement, componentImage{conf.KubeMacPool.Image, "KUBEMACPOOL_IMAGE"})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to determine placement of kubeMacPool")
+	}
+	data.Data["Placement"] = placement
 
-	objs, err := render.RenderDir(filepath.Join(manifestDir, "kubemacpool"), &data)
+	objs, err := renderComponentDir(manifestDir, "kubemacpool", &data)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to render kubeMacPool manifests")
 	}
 
+	if err := overrideResources(objs, conf.KubeMacPool.Resources); err != nil {
+		return nil, errors.Wrap(err, "failed to override resources of kubeMacPool")
+	}
+
 	return objs, nil
 }
 
@@ -0,0 +1,51 @@
+package network
+
+import (
+	"os"
+
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/migration"
+)
+
+// Migrations lists changes of objects deployed by older versions of the operator which cannot be
+// done just by applying the rendered objects. New migrations are appended with the version of the
+// operator introducing them, existing ones must not be renamed, since they are recorded as done.
+func Migrations() []migration.Migration {
+	namespace := os.Getenv("OPERAND_NAMESPACE")
+
+	return []migration.Migration{
+		{
+			// Linux bridge marker used to be deployed as an 'extensions/v1beta1' DaemonSet in its own
+			// namespace. It cannot be upgraded to 'apps/v1' using only Update methods
+			Version: "0.16.0",
+			Name:    "linux-bridge-marker-apps-v1",
+			Delete: []apply.InventoryItem{
+				{Group: "extensions", Version: "v1beta1", Kind: "DaemonSet", Namespace: "linux-bridge", Name: "bridge-marker"},
+			},
+		},
+		{
+			// Multus DaemonSet used to be deployed on amd64 nodes only and was named accordingly. Now
+			// it is a multi-arch DaemonSet with a generic name. The old one is kept until the new one
+			// is running, so nodes are not left without Multus during the upgrade
+			Version: "0.24.0",
+			Name:    "multus-multi-arch",
+			Delete: []apply.InventoryItem{
+				{Group: "apps", Version: "v1", Kind: "DaemonSet", Namespace: namespace, Name: "kube-multus-ds-amd64"},
+			},
+			Replacements: []apply.InventoryItem{
+				{Group: "apps", Version: "v1", Kind: "DaemonSet", Namespace: namespace, Name: "kube-multus-ds"},
+			},
+		},
+		{
+			// The same goes for Ovs
+			Version: "0.24.0",
+			Name:    "ovs-multi-arch",
+			Delete: []apply.InventoryItem{
+				{Group: "apps", Version: "v1", Kind: "DaemonSet", Namespace: namespace, Name: "ovs-cni-amd64"},
+			},
+			Replacements: []apply.InventoryItem{
+				{Group: "apps", Version: "v1", Kind: "DaemonSet", Namespace: namespace, Name: "ovs-cni"},
+			},
+		},
+	}
+}
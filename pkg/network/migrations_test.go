@@ -0,0 +1,93 @@
+package network
+
+import (
+	"context"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/migration"
+)
+
+var _ = Describe("Testing migrations", func() {
+	It("should have unique IDs and valid versions", func() {
+		ids := migration.IDs(Migrations())
+		Expect(ids).To(HaveLen(len(Migrations())))
+		seen := map[string]bool{}
+		for _, id := range ids {
+			Expect(seen).NotTo(HaveKey(id))
+			seen[id] = true
+		}
+
+		_, err := migration.Pending(Migrations(), nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("when DaemonSets deployed by older versions exist", func() {
+		var client k8sclient.Client
+
+		daemonSet := func(name string) *appsv1.DaemonSet {
+			return &appsv1.DaemonSet{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "cluster-network-addons"},
+			}
+		}
+
+		exists := func(name string) bool {
+			err := client.Get(context.TODO(), types.NamespacedName{Namespace: "cluster-network-addons", Name: name}, &appsv1.DaemonSet{})
+			if apierrors.IsNotFound(err) {
+				return false
+			}
+			Expect(err).NotTo(HaveOccurred())
+			return true
+		}
+
+		BeforeEach(func() {
+			os.Setenv("OPERAND_NAMESPACE", "cluster-network-addons")
+			client = fake.NewFakeClient(daemonSet("kube-multus-ds-amd64"), daemonSet("ovs-cni-amd64"), daemonSet("kube-multus-ds"), daemonSet("ovs-cni"))
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("OPERAND_NAMESPACE")
+		})
+
+		Context("and their multi-arch replacements are not deployed yet", func() {
+			BeforeEach(func() {
+				client = fake.NewFakeClient(daemonSet("kube-multus-ds-amd64"), daemonSet("ovs-cni-amd64"))
+			})
+
+			It("should keep the amd64 only DaemonSets", func() {
+				pending, err := migration.Pending(Migrations(), []string{"0.16.0/linux-bridge-marker-apps-v1"})
+				Expect(err).NotTo(HaveOccurred())
+				finished, err := migration.Run(context.TODO(), client, pending)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(finished).To(BeEmpty())
+				Expect(exists("kube-multus-ds-amd64")).To(BeTrue())
+				Expect(exists("ovs-cni-amd64")).To(BeTrue())
+			})
+		})
+
+		It("should remove the amd64 only DaemonSets", func() {
+			pending, err := migration.Pending(Migrations(), []string{"0.16.0/linux-bridge-marker-apps-v1"})
+			Expect(err).NotTo(HaveOccurred())
+			finished, err := migration.Run(context.TODO(), client, pending)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(finished).To(Equal([]string{"0.24.0/multus-multi-arch", "0.24.0/ovs-multi-arch"}))
+
+			for _, name := range []string{"kube-multus-ds-amd64", "ovs-cni-amd64"} {
+				Expect(exists(name)).To(BeFalse(), "DaemonSet %s was not removed", name)
+			}
+			for _, name := range []string{"kube-multus-ds", "ovs-cni"} {
+				Expect(exists(name)).To(BeTrue(), "DaemonSet %s was removed", name)
+			}
+		})
+	})
+})
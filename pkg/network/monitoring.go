@@ -0,0 +1,39 @@
+package network
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/render"
+)
+
+// validateMonitoring checks that ServiceMonitor and PrometheusRule can be deployed, i.e. that
+// Prometheus operator is installed on the cluster
+func validateMonitoring(conf *opv1.NetworkAddonsConfigSpec, clusterInfo *ClusterInfo) []error {
+	if conf.Monitoring == nil || clusterInfo.MonitoringAvailable {
+		return []error{}
+	}
+	return []error{errors.Errorf("monitoring has been requested, but CRDs of Prometheus operator (servicemonitors.monitoring.coreos.com, prometheusrules.monitoring.coreos.com) are not installed")}
+}
+
+// renderMonitoring generates the ServiceMonitor scraping metrics of the operator and the
+// PrometheusRule alerting on them. The metrics Service is created by the operator on start
+func renderMonitoring(conf *opv1.NetworkAddonsConfigSpec, manifestDir string) ([]*unstructured.Unstructured, error) {
+	if conf.Monitoring == nil {
+		return nil, nil
+	}
+
+	data := render.MakeRenderData()
+	data.Data["Namespace"] = os.Getenv("OPERATOR_NAMESPACE")
+	data.Data["OperatorName"] = os.Getenv("OPERATOR_NAME")
+
+	objs, err := renderComponentDir(manifestDir, "monitoring", &data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render monitoring manifests")
+	}
+
+	return objs, nil
+}
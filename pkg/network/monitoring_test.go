@@ -0,0 +1,93 @@
+package network
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+var _ = Describe("Testing Monitoring", func() {
+	Describe("validateMonitoring", func() {
+		conf := &opv1.NetworkAddonsConfigSpec{Monitoring: &opv1.Monitoring{}}
+
+		It("should pass when Prometheus operator is installed", func() {
+			Expect(validateMonitoring(conf, &ClusterInfo{MonitoringAvailable: true})).To(BeEmpty())
+		})
+
+		It("should fail when Prometheus operator is not installed", func() {
+			errs := validateMonitoring(conf, &ClusterInfo{})
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0]).To(MatchError(ContainSubstring("CRDs of Prometheus operator")))
+		})
+
+		It("should pass when monitoring is not requested", func() {
+			Expect(validateMonitoring(&opv1.NetworkAddonsConfigSpec{}, &ClusterInfo{})).To(BeEmpty())
+		})
+	})
+
+	Describe("renderMonitoring", func() {
+		Context("when it is disabled", func() {
+			It("should not render anything", func() {
+				objs, err := renderMonitoring(&opv1.NetworkAddonsConfigSpec{}, "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(objs).To(BeEmpty())
+			})
+		})
+
+		Context("when it is enabled", func() {
+			var objs []*unstructured.Unstructured
+
+			BeforeEach(func() {
+				os.Setenv("OPERATOR_NAMESPACE", "cluster-network-addons")
+				os.Setenv("OPERATOR_NAME", "cluster-network-addons-operator")
+
+				var err error
+				objs, err = renderMonitoring(&opv1.NetworkAddonsConfigSpec{Monitoring: &opv1.Monitoring{}}, "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("OPERATOR_NAMESPACE")
+				os.Unsetenv("OPERATOR_NAME")
+			})
+
+			It("should render a ServiceMonitor selecting the metrics Service of the operator", func() {
+				Expect(objs).To(HaveLen(2))
+				serviceMonitor := objs[0]
+				Expect(serviceMonitor.GetKind()).To(Equal("ServiceMonitor"))
+				Expect(serviceMonitor.GetNamespace()).To(Equal("cluster-network-addons"))
+
+				selector, _, err := unstructured.NestedStringMap(serviceMonitor.Object, "spec", "selector", "matchLabels")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(selector).To(Equal(map[string]string{"name": "cluster-network-addons-operator"}))
+			})
+
+			It("should render a PrometheusRule keeping labels of alerts to be expanded by Prometheus", func() {
+				prometheusRule := objs[1]
+				Expect(prometheusRule.GetKind()).To(Equal("PrometheusRule"))
+
+				groups, _, err := unstructured.NestedSlice(prometheusRule.Object, "spec", "groups")
+				Expect(err).NotTo(HaveOccurred())
+				rules, _, err := unstructured.NestedSlice(groups[0].(map[string]interface{}), "rules")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rules).To(HaveLen(2))
+
+				summary, _, err := unstructured.NestedString(rules[0].(map[string]interface{}), "annotations", "summary")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(summary).To(Equal("Component {{ $labels.component }} of cluster network addons is not available"))
+			})
+		})
+	})
+
+	Describe("RemovedComponents", func() {
+		It("should report removed monitoring", func() {
+			prev := &opv1.NetworkAddonsConfigSpec{Monitoring: &opv1.Monitoring{}}
+			Expect(RemovedComponents(prev, &opv1.NetworkAddonsConfigSpec{})).To(Equal([]string{MonitoringComponent}))
+		})
+	})
+})
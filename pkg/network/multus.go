@@ -1,23 +1,20 @@
 package network
 
 import (
-	"context"
 	"os"
-	"path/filepath"
 	"reflect"
 
 	osv1 "github.com/openshift/api/operator/v1"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
-	opv1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
 	"github.com/kubevirt/cluster-network-addons-operator/pkg/network/cni"
 	"github.com/kubevirt/cluster-network-addons-operator/pkg/render"
 )
 
 // ValidateMultus validates the combination of DisableMultiNetwork and AddtionalNetworks
-func validateMultus(conf *opv1alpha1.NetworkAddonsConfigSpec, openshiftNetworkConfig *osv1.Network) []error {
+func validateMultus(conf *opv1.NetworkAddonsConfigSpec, openshiftNetworkConfig *osv1.Network) []error {
 	if conf.Multus == nil {
 		return []error{}
 	}
@@ -31,20 +28,15 @@ func validateMultus(conf *opv1alpha1.NetworkAddonsConfigSpec, openshiftNetworkCo
 	return []error{}
 }
 
-func changeSafeMultus(prev, next *opv1alpha1.NetworkAddonsConfigSpec) []error {
-	if prev.Multus != nil && !reflect.DeepEqual(prev.Multus, next.Multus) {
+func changeSafeMultus(prev, next *opv1.NetworkAddonsConfigSpec) []error {
+	if prev.Multus != nil && next.Multus != nil && !reflect.DeepEqual(prev.Multus, next.Multus) {
 		return []error{errors.Errorf("cannot modify Multus configuration once it is deployed")}
 	}
 	return nil
 }
 
-// Currently not implemented, since there are no obsolete objects under this module
-func CleanUpMultus(ctx context.Context, client k8sclient.Client, objs []*unstructured.Unstructured) []error {
-	return nil
-}
-
 // RenderMultus generates the manifests of Multus
-func renderMultus(conf *opv1alpha1.NetworkAddonsConfigSpec, manifestDir string, openshiftNetworkConfig *osv1.Network, clusterInfo *ClusterInfo) ([]*unstructured.Unstructured, error) {
+func renderMultus(conf *opv1.NetworkAddonsConfigSpec, manifestDir string, openshiftNetworkConfig *osv1.Network, clusterInfo *ClusterInfo) ([]*unstructured.Unstructured, error) {
 	if conf.Multus == nil || openshiftNetworkConfig != nil {
 		return nil, nil
 	}
@@ -52,8 +44,13 @@ func renderMultus(conf *opv1alpha1.NetworkAddonsConfigSpec, manifestDir string,
 	// render manifests from disk
 	data := render.MakeRenderData()
 	data.Data["Namespace"] = os.Getenv("OPERAND_NAMESPACE")
-	data.Data["MultusImage"] = os.Getenv("MULTUS_IMAGE")
+	data.Data["MultusImage"] = image(conf.Multus.Image, "MULTUS_IMAGE")
 	data.Data["ImagePullPolicy"] = conf.ImagePullPolicy
+	placement, err := componentPlacement(defaultMultusPlacement(), conf.Placement, conf.Multus.Placement, componentImage{conf.Multus.Image, "MULTUS_IMAGE"})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to determine placement of multus")
+	}
+	data.Data["Placement"] = placement
 	if clusterInfo.OpenShift4 {
 		data.Data["CNIConfigDir"] = cni.ConfigDirOpenShift4
 		data.Data["CNIBinDir"] = cni.BinDirOpenShift4
@@ -63,10 +60,14 @@ func renderMultus(conf *opv1alpha1.NetworkAddonsConfigSpec, manifestDir string,
 	}
 	data.Data["EnableSCC"] = clusterInfo.SCCAvailable
 
-	objs, err := render.RenderDir(filepath.Join(manifestDir, "multus"), &data)
+	objs, err := renderComponentDir(manifestDir, "multus", &data)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to render multus manifests")
 	}
 
+	if err := overrideResources(objs, conf.Multus.Resources); err != nil {
+		return nil, errors.Wrap(err, "failed to override resources of multus")
+	}
+
 	return objs, nil
 }
@@ -6,7 +6,7 @@ import (
 
 	osv1 "github.com/openshift/api/operator/v1"
 
-	opv1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
 )
 
 var _ = Describe("Testing multus", func() {
@@ -16,9 +16,9 @@ var _ = Describe("Testing multus", func() {
 			openshiftNetworkOperatorRunning bool,
 			openshiftNetworkOperatorDisableMultiNetwork bool,
 		) []error {
-			conf := &opv1alpha1.NetworkAddonsConfigSpec{}
+			conf := &opv1.NetworkAddonsConfigSpec{}
 			if multusRequested {
-				conf.Multus = &opv1alpha1.Multus{}
+				conf.Multus = &opv1.Multus{}
 			}
 
 			var openshiftNetworkConfig *osv1.Network
@@ -108,8 +108,8 @@ var _ = Describe("Testing multus", func() {
 
 	Describe("changeSafeMultus", func() {
 		Context("when it is kept disabled", func() {
-			prev := &opv1alpha1.NetworkAddonsConfigSpec{}
-			new := &opv1alpha1.NetworkAddonsConfigSpec{}
+			prev := &opv1.NetworkAddonsConfigSpec{}
+			new := &opv1.NetworkAddonsConfigSpec{}
 			It("should pass", func() {
 				errorList := changeSafeMultus(prev, new)
 				Expect(errorList).To(BeEmpty())
@@ -117,8 +117,8 @@ var _ = Describe("Testing multus", func() {
 		})
 
 		Context("when there is no previous value", func() {
-			prev := &opv1alpha1.NetworkAddonsConfigSpec{}
-			new := &opv1alpha1.NetworkAddonsConfigSpec{Multus: &opv1alpha1.Multus{}}
+			prev := &opv1.NetworkAddonsConfigSpec{}
+			new := &opv1.NetworkAddonsConfigSpec{Multus: &opv1.Multus{}}
 			It("should accept any configuration", func() {
 				errorList := changeSafeMultus(prev, new)
 				Expect(errorList).To(BeEmpty())
@@ -126,8 +126,8 @@ var _ = Describe("Testing multus", func() {
 		})
 
 		Context("when the previous and new configuration match", func() {
-			prev := &opv1alpha1.NetworkAddonsConfigSpec{Multus: &opv1alpha1.Multus{}}
-			new := &opv1alpha1.NetworkAddonsConfigSpec{Multus: &opv1alpha1.Multus{}}
+			prev := &opv1.NetworkAddonsConfigSpec{Multus: &opv1.Multus{}}
+			new := &opv1.NetworkAddonsConfigSpec{Multus: &opv1.Multus{}}
 			It("should accept the configuration", func() {
 				errorList := changeSafeMultus(prev, new)
 				Expect(errorList).To(BeEmpty())
@@ -135,12 +135,11 @@ var _ = Describe("Testing multus", func() {
 		})
 
 		Context("when there is previous value, but the new one is empty (removing component)", func() {
-			prev := &opv1alpha1.NetworkAddonsConfigSpec{Multus: &opv1alpha1.Multus{}}
-			new := &opv1alpha1.NetworkAddonsConfigSpec{}
-			It("should fail", func() {
+			prev := &opv1.NetworkAddonsConfigSpec{Multus: &opv1.Multus{}}
+			new := &opv1.NetworkAddonsConfigSpec{}
+			It("should accept the configuration", func() {
 				errorList := changeSafeMultus(prev, new)
-				Expect(len(errorList)).To(Equal(1), "validation failed due to an unexpected error: %v", errorList)
-				Expect(errorList[0].Error()).To(Equal("cannot modify Multus configuration once it is deployed"))
+				Expect(errorList).To(BeEmpty())
 			})
 		})
 	})
@@ -1,32 +1,38 @@
 package network
 
 import (
-	"context"
 	"log"
+	"path/filepath"
 	"reflect"
 	"strings"
 
 	osv1 "github.com/openshift/api/operator/v1"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
-	opv1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/render"
 )
 
 // Canonicalize converts configuration to a canonical form.
-func Canonicalize(conf *opv1alpha1.NetworkAddonsConfigSpec) {
+func Canonicalize(conf *opv1.NetworkAddonsConfigSpec) {
 	// TODO
 }
 
 // Validate checks that the supplied configuration is reasonable.
 // This should be called after Canonicalize
-func Validate(conf *opv1alpha1.NetworkAddonsConfigSpec, openshiftNetworkConfig *osv1.Network) error {
+func Validate(conf *opv1.NetworkAddonsConfigSpec, openshiftNetworkConfig *osv1.Network, clusterInfo *ClusterInfo) error {
 	errs := []error{}
 
 	errs = append(errs, validateMultus(conf, openshiftNetworkConfig)...)
 	errs = append(errs, validateKubeMacPool(conf)...)
 	errs = append(errs, validateImagePullPolicy(conf)...)
+	errs = append(errs, validatePlacements(conf)...)
+	errs = append(errs, validateResources(conf)...)
+	errs = append(errs, validateImages(conf)...)
+	errs = append(errs, validatePatches(conf)...)
+	errs = append(errs, validateProgressDeadlines(conf)...)
+	errs = append(errs, validateMonitoring(conf, clusterInfo)...)
 
 	if len(errs) > 0 {
 		return errors.Errorf("invalid configuration:\n%s", errorListToMultiLineString(errs))
@@ -39,11 +45,12 @@ func Validate(conf *opv1alpha1.NetworkAddonsConfigSpec, openshiftNetworkConfig *
 //
 // Defaults are carried forward from previous if it is provided. This is so we
 // can change defaults as we move forward, but won't disrupt existing clusters.
-func FillDefaults(conf, previous *opv1alpha1.NetworkAddonsConfigSpec) error {
+func FillDefaults(conf, previous *opv1.NetworkAddonsConfigSpec) error {
 	errs := []error{}
 
 	errs = append(errs, fillDefaultsImagePullPolicy(conf, previous)...)
 	errs = append(errs, fillDefaultsKubeMacPool(conf, previous)...)
+	errs = append(errs, fillDefaultsProgressDeadlines(conf, previous)...)
 
 	if len(errs) > 0 {
 		return errors.Errorf("invalid configuration:\n%s", errorListToMultiLineString(errs))
@@ -52,31 +59,9 @@ func FillDefaults(conf, previous *opv1alpha1.NetworkAddonsConfigSpec) error {
 	return nil
 }
 
-// specialCleanUp checks if there are any outdated objects and deletes them.
-// This means that the object wil be installed as new and not by the upgrade kubectl algorithm
-// The cleanUp is performed on specific obsoleted objects, so some of the cleanUp functions may be not implemented.
-func SpecialCleanUp(networkAddonsConfig *opv1alpha1.NetworkAddonsConfig, client k8sclient.Client, objs []*unstructured.Unstructured) error {
-
-	errs := []error{}
-	ctx := context.TODO()
-
-	errs = append(errs, CleanUpMultus(ctx, client, objs)...)
-	errs = append(errs, CleanUpLinuxBridge(ctx, client, objs)...)
-	errs = append(errs, CleanUpKubeMacPool(ctx, client, objs)...)
-	errs = append(errs, CleanUpImagePullPolicy(ctx, client, objs)...)
-	errs = append(errs, CleanUpNMState(ctx, client, objs)...)
-	errs = append(errs, CleanUpOvs(ctx, client, objs)...)
-
-	if len(errs) > 0 {
-		return errors.Errorf("invalid configuration:\n%v", errs)
-	}
-
-	return nil
-}
-
 // IsChangeSafe checks to see if the change between prev and next are allowed
 // FillDefaults and Validate should have been called.
-func IsChangeSafe(prev, next *opv1alpha1.NetworkAddonsConfigSpec) error {
+func IsChangeSafe(prev, next *opv1.NetworkAddonsConfigSpec) error {
 	if prev == nil {
 		return nil
 	}
@@ -86,6 +71,9 @@ func IsChangeSafe(prev, next *opv1alpha1.NetworkAddonsConfigSpec) error {
 		return nil
 	}
 
+	// Some fields can be changed at any time, they are therefore not checked by components
+	prev, next = withoutMutableFields(prev), withoutMutableFields(next)
+
 	errs := []error{}
 
 	errs = append(errs, changeSafeMultus(prev, next)...)
@@ -101,47 +89,130 @@ func IsChangeSafe(prev, next *opv1alpha1.NetworkAddonsConfigSpec) error {
 	return nil
 }
 
-func Render(conf *opv1alpha1.NetworkAddonsConfigSpec, manifestDir string, openshiftNetworkConfig *osv1.Network, clusterInfo *ClusterInfo) ([]*unstructured.Unstructured, error) {
-	log.Print("starting render phase")
+// withoutMutableFields returns a copy of the configuration without fields which can be modified
+// on a running cluster, such as placement, resources, images or progress deadlines. Changed pods are simply rolled out
+func withoutMutableFields(conf *opv1.NetworkAddonsConfigSpec) *opv1.NetworkAddonsConfigSpec {
+	conf = conf.DeepCopy()
+	conf.Placement = nil
+	if conf.Multus != nil {
+		conf.Multus.Placement = nil
+		conf.Multus.Resources = nil
+		conf.Multus.Image = ""
+		conf.Multus.ProgressDeadline = nil
+	}
+	if conf.LinuxBridge != nil {
+		conf.LinuxBridge.Placement = nil
+		conf.LinuxBridge.Resources = nil
+		conf.LinuxBridge.Image = ""
+		conf.LinuxBridge.MarkerImage = ""
+		conf.LinuxBridge.ProgressDeadline = nil
+	}
+	if conf.Ovs != nil {
+		conf.Ovs.Placement = nil
+		conf.Ovs.Resources = nil
+		conf.Ovs.Image = ""
+		conf.Ovs.MarkerImage = ""
+		conf.Ovs.ProgressDeadline = nil
+	}
+	if conf.KubeMacPool != nil {
+		conf.KubeMacPool.Placement = nil
+		conf.KubeMacPool.Resources = nil
+		conf.KubeMacPool.Image = ""
+		conf.KubeMacPool.ProgressDeadline = nil
+	}
+	if conf.NMState != nil {
+		conf.NMState.Placement = nil
+		conf.NMState.Resources = nil
+		conf.NMState.Image = ""
+		conf.NMState.ProgressDeadline = nil
+	}
+	return conf
+}
+
+// RenderedComponent holds objects rendered for one of the configured components
+type RenderedComponent struct {
+	Name    string
+	Objects []*unstructured.Unstructured
+}
+
+// Render generates manifests of all configured components. Templates are read
+// from manifestDir when it is set, otherwise those compiled into the binary are used
+func Render(conf *opv1.NetworkAddonsConfigSpec, manifestDir string, openshiftNetworkConfig *osv1.Network, clusterInfo *ClusterInfo) ([]*unstructured.Unstructured, error) {
+	components, err := RenderComponents(conf, manifestDir, openshiftNetworkConfig, clusterInfo)
+	if err != nil {
+		return nil, err
+	}
+
 	objs := []*unstructured.Unstructured{}
+	for _, component := range components {
+		objs = append(objs, component.Objects...)
+	}
+	return objs, nil
+}
+
+// RenderComponents generates manifests of all configured components the same way as Render does,
+// keeping track of the component each object was rendered for. Objects shared by several
+// components, such as their namespace, are listed under each of them
+func RenderComponents(conf *opv1.NetworkAddonsConfigSpec, manifestDir string, openshiftNetworkConfig *osv1.Network, clusterInfo *ClusterInfo) ([]RenderedComponent, error) {
+	log.Print("starting render phase")
+	components := []RenderedComponent{}
+	count := 0
 
 	// render Multus
 	o, err := renderMultus(conf, manifestDir, openshiftNetworkConfig, clusterInfo)
 	if err != nil {
 		return nil, err
 	}
-	objs = append(objs, o...)
+	labelWorkloads(o, MultusComponent)
+	components = append(components, RenderedComponent{Name: MultusComponent, Objects: o})
+	count += len(o)
 
 	// render Linux Bridge
 	o, err = renderLinuxBridge(conf, manifestDir, clusterInfo)
 	if err != nil {
 		return nil, err
 	}
-	objs = append(objs, o...)
+	labelWorkloads(o, LinuxBridgeComponent)
+	components = append(components, RenderedComponent{Name: LinuxBridgeComponent, Objects: o})
+	count += len(o)
 
 	// render kubeMacPool
 	o, err = renderKubeMacPool(conf, manifestDir)
 	if err != nil {
 		return nil, err
 	}
-	objs = append(objs, o...)
+	labelWorkloads(o, KubeMacPoolComponent)
+	components = append(components, RenderedComponent{Name: KubeMacPoolComponent, Objects: o})
+	count += len(o)
 
 	// render NMState
 	o, err = renderNMState(conf, manifestDir, clusterInfo)
 	if err != nil {
 		return nil, err
 	}
-	objs = append(objs, o...)
+	labelWorkloads(o, NMStateComponent)
+	components = append(components, RenderedComponent{Name: NMStateComponent, Objects: o})
+	count += len(o)
 
 	// render Ovs
 	o, err = renderOvs(conf, manifestDir, clusterInfo)
 	if err != nil {
 		return nil, err
 	}
-	objs = append(objs, o...)
+	labelWorkloads(o, OvsComponent)
+	components = append(components, RenderedComponent{Name: OvsComponent, Objects: o})
+	count += len(o)
 
-	log.Printf("render phase done, rendered %d objects", len(objs))
-	return objs, nil
+	// render Monitoring
+	o, err = renderMonitoring(conf, manifestDir)
+	if err != nil {
+		return nil, err
+	}
+	components = append(components, RenderedComponent{Name: MonitoringComponent, Objects: o})
+	count += len(o)
+
+	log.Printf("render phase done, rendered %d objects", count)
+	return components, nil
 }
 
 func errorListToMultiLineString(errs []error) string {
@@ -151,3 +222,12 @@ func errorListToMultiLineString(errs []error) string {
 	}
 	return strings.Join(stringErrs, "\n")
 }
+
+// renderComponentDir renders manifest templates of the given component, either
+// from manifestDir on the disk or, if it is empty, from the embedded data directory
+func renderComponentDir(manifestDir, component string, data *render.RenderData) ([]*unstructured.Unstructured, error) {
+	if manifestDir == "" {
+		return render.RenderEmbeddedDir(component, data)
+	}
+	return render.RenderDir(filepath.Join(manifestDir, component), data)
+}
@@ -1,33 +1,25 @@
 package network
 
 import (
-	"context"
 	"os"
-	"path/filepath"
 	"reflect"
 
 	"github.com/kubevirt/cluster-network-addons-operator/pkg/render"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
-	opv1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
 )
 
-func changeSafeNMState(prev, next *opv1alpha1.NetworkAddonsConfigSpec) []error {
-	if prev.NMState != nil && !reflect.DeepEqual(prev.NMState, next.NMState) {
+func changeSafeNMState(prev, next *opv1.NetworkAddonsConfigSpec) []error {
+	if prev.NMState != nil && next.NMState != nil && !reflect.DeepEqual(prev.NMState, next.NMState) {
 		return []error{errors.Errorf("cannot modify NMState state handler configuration once it is deployed")}
 	}
 	return nil
 }
 
-// Currently not implemented, since there are no obsolete objects under this module
-func CleanUpNMState(ctx context.Context, client k8sclient.Client, objs []*unstructured.Unstructured) []error {
-	return nil
-}
-
 // renderNMState generates the manifests of NMState handler
-func renderNMState(conf *opv1alpha1.NetworkAddonsConfigSpec, manifestDir string, clusterInfo *ClusterInfo) ([]*unstructured.Unstructured, error) {
+func renderNMState(conf *opv1.NetworkAddonsConfigSpec, manifestDir string, clusterInfo *ClusterInfo) ([]*unstructured.Unstructured, error) {
 	if conf.NMState == nil {
 		return nil, nil
 	}
@@ -35,14 +27,23 @@ func renderNMState(conf *opv1alpha1.NetworkAddonsConfigSpec, manifestDir string,
 	// render the manifests on disk
 	data := render.MakeRenderData()
 	data.Data["Namespace"] = os.Getenv("OPERAND_NAMESPACE")
-	data.Data["NMStateHandlerImage"] = os.Getenv("NMSTATE_HANDLER_IMAGE")
+	data.Data["NMStateHandlerImage"] = image(conf.NMState.Image, "NMSTATE_HANDLER_IMAGE")
 	data.Data["ImagePullPolicy"] = conf.ImagePullPolicy
+	placement, err := componentPlacement(defaultNodePlacement(), conf.Placement, conf.NMState.Placement, componentImage{conf.NMState.Image, "NMSTATE_HANDLER_IMAGE"})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to determine placement of nmstate state handler")
+	}
+	data.Data["Placement"] = placement
 	data.Data["EnableSCC"] = clusterInfo.SCCAvailable
 
-	objs, err := render.RenderDir(filepath.Join(manifestDir, "nmstate"), &data)
+	objs, err := renderComponentDir(manifestDir, "nmstate", &data)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to render nmstate state handler manifests")
 	}
 
+	if err := overrideResources(objs, conf.NMState.Resources); err != nil {
+		return nil, errors.Wrap(err, "failed to override resources of nmstate state handler")
+	}
+
 	return objs, nil
 }
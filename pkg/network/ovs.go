@@ -1,34 +1,26 @@
 package network
 
 import (
-	"context"
 	"os"
-	"path/filepath"
 	"reflect"
 
 	"github.com/kubevirt/cluster-network-addons-operator/pkg/render"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
-	opv1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
 	"github.com/kubevirt/cluster-network-addons-operator/pkg/network/cni"
 )
 
-func changeSafeOvs(prev, next *opv1alpha1.NetworkAddonsConfigSpec) []error {
-	if prev.Ovs != nil && !reflect.DeepEqual(prev.Ovs, next.Ovs) {
+func changeSafeOvs(prev, next *opv1.NetworkAddonsConfigSpec) []error {
+	if prev.Ovs != nil && next.Ovs != nil && !reflect.DeepEqual(prev.Ovs, next.Ovs) {
 		return []error{errors.Errorf("cannot modify Ovs configuration once it is deployed")}
 	}
 	return nil
 }
 
-// Currently not implemented, since there are no obsolete objects under this module
-func CleanUpOvs(ctx context.Context, client k8sclient.Client, objs []*unstructured.Unstructured) []error {
-	return nil
-}
-
 // renderOvs generates the manifests of Ovs
-func renderOvs(conf *opv1alpha1.NetworkAddonsConfigSpec, manifestDir string, clusterInfo *ClusterInfo) ([]*unstructured.Unstructured, error) {
+func renderOvs(conf *opv1.NetworkAddonsConfigSpec, manifestDir string, clusterInfo *ClusterInfo) ([]*unstructured.Unstructured, error) {
 	if conf.Ovs == nil {
 		return nil, nil
 	}
@@ -36,10 +28,15 @@ func renderOvs(conf *opv1alpha1.NetworkAddonsConfigSpec, manifestDir string, clu
 	// render the manifests on disk
 	data := render.MakeRenderData()
 	data.Data["Namespace"] = os.Getenv("OPERAND_NAMESPACE")
-	data.Data["OvsCNIImage"] = os.Getenv("OVS_CNI_IMAGE")
-	data.Data["OvsMarkerImage"] = os.Getenv("OVS_MARKER_IMAGE")
+	data.Data["OvsCNIImage"] = image(conf.Ovs.Image, "OVS_CNI_IMAGE")
+	data.Data["OvsMarkerImage"] = image(conf.Ovs.MarkerImage, "OVS_MARKER_IMAGE")
 	data.Data["OvsImage"] = os.Getenv("OVS_IMAGE")
 	data.Data["ImagePullPolicy"] = conf.ImagePullPolicy
+	placement, err := componentPlacement(defaultNodePlacement(), conf.Placement, conf.Ovs.Placement, componentImage{conf.Ovs.Image, "OVS_CNI_IMAGE"}, componentImage{conf.Ovs.MarkerImage, "OVS_MARKER_IMAGE"})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to determine placement of ovs")
+	}
+	data.Data["Placement"] = placement
 	if clusterInfo.OpenShift4 {
 		data.Data["CNIBinDir"] = cni.BinDirOpenShift4
 	} else {
@@ -47,10 +44,14 @@ func renderOvs(conf *opv1alpha1.NetworkAddonsConfigSpec, manifestDir string, clu
 	}
 	data.Data["EnableSCC"] = clusterInfo.SCCAvailable
 
-	objs, err := render.RenderDir(filepath.Join(manifestDir, "ovs"), &data)
+	objs, err := renderComponentDir(manifestDir, "ovs", &data)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to render ovs manifests")
 	}
 
+	if err := overrideResources(objs, conf.Ovs.Resources); err != nil {
+		return nil, errors.Wrap(err, "failed to override resources of ovs")
+	}
+
 	return objs, nil
 }
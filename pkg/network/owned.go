@@ -0,0 +1,43 @@
+package network
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+// OwnedLabel marks all objects rendered by the operator, so only those are watched for
+// modifications, see OwnedKinds. Objects of the same kinds deployed by others are not cached
+var OwnedLabel = opv1.SchemeGroupVersion.Group + "/owned"
+
+// OwnedKinds lists kinds of objects which may be rendered by the operator. Objects of these kinds
+// are watched, so they can be restored when somebody modifies or removes them. Kinds are compared
+// by their group, the version is the one served by all supported clusters.
+func OwnedKinds(clusterInfo *ClusterInfo) []schema.GroupVersionKind {
+	kinds := []schema.GroupVersionKind{
+		{Group: "", Version: "v1", Kind: "Namespace"},
+		{Group: "", Version: "v1", Kind: "ServiceAccount"},
+		{Group: "", Version: "v1", Kind: "ConfigMap"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRoleBinding"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "Role"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "RoleBinding"},
+		{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+		{Group: "apps", Version: "v1", Kind: "Deployment"},
+		{Group: "policy", Version: "v1beta1", Kind: "PodDisruptionBudget"},
+		{Group: "apiextensions.k8s.io", Version: "v1beta1", Kind: "CustomResourceDefinition"},
+	}
+
+	if clusterInfo.SCCAvailable {
+		kinds = append(kinds, schema.GroupVersionKind{Group: "security.openshift.io", Version: "v1", Kind: "SecurityContextConstraints"})
+	}
+
+	if clusterInfo.MonitoringAvailable {
+		kinds = append(kinds,
+			schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"},
+			schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"},
+		)
+	}
+
+	return kinds
+}
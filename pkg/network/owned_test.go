@@ -0,0 +1,55 @@
+package network
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+var _ = Describe("Testing owned kinds", func() {
+	conf := &opv1.NetworkAddonsConfigSpec{
+		ImagePullPolicy: v1.PullAlways,
+		Multus:          &opv1.Multus{},
+		LinuxBridge:     &opv1.LinuxBridge{},
+		KubeMacPool:     &opv1.KubeMacPool{RangeStart: "02:00:00:00:00:00", RangeEnd: "02:FF:FF:FF:FF:FF"},
+		NMState:         &opv1.NMState{},
+		Ovs:             &opv1.Ovs{},
+		Monitoring:      &opv1.Monitoring{},
+	}
+
+	BeforeEach(func() {
+		os.Setenv("OPERAND_NAMESPACE", "cluster-network-addons")
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("OPERAND_NAMESPACE")
+	})
+
+	ownedGroupKinds := func(clusterInfo *ClusterInfo) map[schema.GroupKind]bool {
+		groupKinds := map[schema.GroupKind]bool{}
+		for _, gvk := range OwnedKinds(clusterInfo) {
+			groupKinds[gvk.GroupKind()] = true
+		}
+		return groupKinds
+	}
+
+	for _, clusterInfo := range []*ClusterInfo{{SCCAvailable: true, MonitoringAvailable: true}, {SCCAvailable: false, MonitoringAvailable: true}} {
+		clusterInfo := clusterInfo
+
+		It("should cover all rendered objects", func() {
+			objs, err := Render(conf, "../../data", nil, clusterInfo)
+			Expect(err).NotTo(HaveOccurred())
+
+			owned := ownedGroupKinds(clusterInfo)
+			for _, obj := range objs {
+				Expect(owned).To(HaveKey(obj.GroupVersionKind().GroupKind()), "%s %s is not watched", obj.GetKind(), obj.GetName())
+			}
+		})
+	}
+})
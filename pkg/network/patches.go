@@ -0,0 +1,122 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
+)
+
+var patchTypes = map[opv1.PatchType]types.PatchType{
+	opv1.PatchTypeStrategicMerge: types.StrategicMergePatchType,
+	opv1.PatchTypeJSON:           types.JSONPatchType,
+}
+
+func validatePatches(conf *opv1.NetworkAddonsConfigSpec) []error {
+	errs := []error{}
+
+	for i, patch := range conf.Patches {
+		path := fmt.Sprintf("patches[%d]", i)
+
+		if patch.Target.APIVersion == "" {
+			errs = append(errs, errors.Errorf("%s.target.apiVersion must be set", path))
+		}
+		if patch.Target.Kind == "" {
+			errs = append(errs, errors.Errorf("%s.target.kind must be set", path))
+		}
+		if patch.Target.Name == "" {
+			errs = append(errs, errors.Errorf("%s.target.name must be set", path))
+		}
+
+		if _, err := patchContent(patch); err != nil {
+			errs = append(errs, errors.Wrapf(err, "%s.patch is invalid", path))
+		}
+	}
+
+	return errs
+}
+
+// patchContent converts the patch to JSON and checks that it is well-formed for its type
+func patchContent(patch opv1.Patch) ([]byte, error) {
+	content, err := yaml.YAMLToJSON([]byte(patch.Patch))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse patch")
+	}
+
+	switch patch.Type {
+	case opv1.PatchTypeStrategicMerge:
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(content, &fields); err != nil {
+			return nil, errors.Wrap(err, "strategic merge patch must be an object")
+		}
+	case opv1.PatchTypeJSON:
+		if _, err := jsonpatch.DecodePatch(content); err != nil {
+			return nil, errors.Wrap(err, "JSON patch must be a list of operations")
+		}
+	default:
+		return nil, errors.Errorf("unknown type '%s', expected one of %s, %s", patch.Type, opv1.PatchTypeStrategicMerge, opv1.PatchTypeJSON)
+	}
+
+	return content, nil
+}
+
+// ApplyPatches applies patches of the configuration on rendered objects in the given order. Each
+// patch has to target one of the objects
+func ApplyPatches(conf *opv1.NetworkAddonsConfigSpec, objs []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	patched := make([]*unstructured.Unstructured, len(objs))
+	copy(patched, objs)
+
+	for i, patch := range conf.Patches {
+		content, err := patchContent(patch)
+		if err != nil {
+			return nil, errors.Wrapf(err, "patches[%d] is invalid", i)
+		}
+
+		found := false
+		for j, obj := range patched {
+			if !isPatchTarget(patch.Target, obj) {
+				continue
+			}
+			found = true
+
+			patched[j], err = apply.PatchObject(obj, patchTypes[patch.Type], content)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to apply patches[%d] on (%s) %s/%s", i, obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+			}
+		}
+
+		if !found {
+			target := patch.Target
+			return nil, errors.Errorf("patches[%d] targets (%s, Kind=%s) %s/%s, which is not rendered", i, target.APIVersion, target.Kind, target.Namespace, target.Name)
+		}
+	}
+
+	return patched, nil
+}
+
+// AppliedPatches lists patches of the configuration as they are reported in the status
+func AppliedPatches(conf *opv1.NetworkAddonsConfigSpec) []opv1.AppliedPatch {
+	if len(conf.Patches) == 0 {
+		return nil
+	}
+
+	applied := []opv1.AppliedPatch{}
+	for _, patch := range conf.Patches {
+		applied = append(applied, opv1.AppliedPatch{Target: patch.Target, Type: patch.Type})
+	}
+	return applied
+}
+
+func isPatchTarget(target opv1.PatchTarget, obj *unstructured.Unstructured) bool {
+	return obj.GetAPIVersion() == target.APIVersion &&
+		obj.GetKind() == target.Kind &&
+		obj.GetNamespace() == target.Namespace &&
+		obj.GetName() == target.Name
+}
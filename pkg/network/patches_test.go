@@ -0,0 +1,208 @@
+package network
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+var _ = Describe("Testing patches", func() {
+	clusterInfo := &ClusterInfo{SCCAvailable: true, OpenShift4: false}
+
+	findObject := func(objs []*unstructured.Unstructured, kind, name string) *unstructured.Unstructured {
+		for _, obj := range objs {
+			if obj.GetKind() == kind && obj.GetName() == name {
+				return obj
+			}
+		}
+		Fail(kind + " " + name + " was not rendered")
+		return nil
+	}
+
+	podSpecOf := func(obj *unstructured.Unstructured) v1.PodSpec {
+		spec, _, err := unstructured.NestedMap(obj.Object, "spec", "template", "spec")
+		Expect(err).NotTo(HaveOccurred())
+		podSpec := v1.PodSpec{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(spec, &podSpec)).To(Succeed())
+		return podSpec
+	}
+
+	ovsTarget := func(objs []*unstructured.Unstructured) opv1.PatchTarget {
+		daemonSet := findObject(objs, "DaemonSet", "ovs-cni")
+		return opv1.PatchTarget{APIVersion: "apps/v1", Kind: "DaemonSet", Namespace: daemonSet.GetNamespace(), Name: "ovs-cni"}
+	}
+
+	Describe("validatePatches", func() {
+		Context("when patches are well-formed", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{
+				Patches: []opv1.Patch{
+					{
+						Target: opv1.PatchTarget{APIVersion: "apps/v1", Kind: "DaemonSet", Namespace: "ns", Name: "ovs-cni"},
+						Type:   opv1.PatchTypeStrategicMerge,
+						Patch:  "metadata:\n  annotations:\n    foo: bar\n",
+					},
+					{
+						Target: opv1.PatchTarget{APIVersion: "v1", Kind: "Namespace", Name: "ns"},
+						Type:   opv1.PatchTypeJSON,
+						Patch:  `[{"op": "add", "path": "/metadata/labels/foo", "value": "bar"}]`,
+					},
+				},
+			}
+
+			It("should pass", func() {
+				Expect(validatePatches(conf)).To(BeEmpty())
+			})
+		})
+
+		Context("when patches are malformed", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{
+				Patches: []opv1.Patch{
+					{
+						Target: opv1.PatchTarget{Kind: "DaemonSet"},
+						Type:   opv1.PatchTypeStrategicMerge,
+						Patch:  "- foo",
+					},
+					{
+						Target: opv1.PatchTarget{APIVersion: "v1", Kind: "Namespace", Name: "ns"},
+						Type:   opv1.PatchTypeJSON,
+						Patch:  `{"op": "add"}`,
+					},
+					{
+						Target: opv1.PatchTarget{APIVersion: "v1", Kind: "Namespace", Name: "ns"},
+						Type:   "Merge",
+						Patch:  "{}",
+					},
+				},
+			}
+
+			It("should report all issues", func() {
+				errs := validatePatches(conf)
+				Expect(errs).To(HaveLen(5))
+				Expect(errs[0]).To(MatchError("patches[0].target.apiVersion must be set"))
+				Expect(errs[1]).To(MatchError("patches[0].target.name must be set"))
+				Expect(errs[2]).To(MatchError(ContainSubstring("patches[0].patch is invalid: strategic merge patch must be an object")))
+				Expect(errs[3]).To(MatchError(ContainSubstring("patches[1].patch is invalid: JSON patch must be a list of operations")))
+				Expect(errs[4]).To(MatchError(ContainSubstring("patches[2].patch is invalid: unknown type 'Merge'")))
+			})
+		})
+	})
+
+	Describe("ApplyPatches", func() {
+		conf := &opv1.NetworkAddonsConfigSpec{ImagePullPolicy: v1.PullAlways, Ovs: &opv1.Ovs{}}
+		var objs []*unstructured.Unstructured
+
+		BeforeEach(func() {
+			var err error
+			objs, err = Render(conf, "../../data", nil, clusterInfo)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when a strategic merge patch targets a rendered object", func() {
+			It("should merge it into the object and keep the original intact", func() {
+				patchConf := conf.DeepCopy()
+				patchConf.Patches = []opv1.Patch{
+					{
+						Target: ovsTarget(objs),
+						Type:   opv1.PatchTypeStrategicMerge,
+						Patch: `
+spec:
+  template:
+    spec:
+      containers:
+      - name: ovs-cni-marker
+        env:
+        - name: FOO
+          value: bar
+`,
+					},
+				}
+
+				patched, err := ApplyPatches(patchConf, objs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(patched).To(HaveLen(len(objs)))
+
+				podSpec := podSpecOf(findObject(patched, "DaemonSet", "ovs-cni"))
+				Expect(podSpec.Containers).To(HaveLen(2), "containers should be merged by name")
+				for _, container := range podSpec.Containers {
+					if container.Name == "ovs-cni-marker" {
+						Expect(container.Env).To(ContainElement(v1.EnvVar{Name: "FOO", Value: "bar"}))
+						Expect(container.ImagePullPolicy).To(Equal(v1.PullAlways))
+					}
+				}
+
+				for _, container := range podSpecOf(findObject(objs, "DaemonSet", "ovs-cni")).Containers {
+					Expect(container.Env).NotTo(ContainElement(v1.EnvVar{Name: "FOO", Value: "bar"}))
+				}
+			})
+		})
+
+		Context("when patches are chained", func() {
+			It("should apply them in order", func() {
+				patchConf := conf.DeepCopy()
+				patchConf.Patches = []opv1.Patch{
+					{
+						Target: ovsTarget(objs),
+						Type:   opv1.PatchTypeJSON,
+						Patch:  `[{"op": "add", "path": "/metadata/annotations", "value": {"foo": "bar"}}]`,
+					},
+					{
+						Target: ovsTarget(objs),
+						Type:   opv1.PatchTypeJSON,
+						Patch:  `[{"op": "replace", "path": "/metadata/annotations/foo", "value": "baz"}]`,
+					},
+				}
+
+				patched, err := ApplyPatches(patchConf, objs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(findObject(patched, "DaemonSet", "ovs-cni").GetAnnotations()).To(HaveKeyWithValue("foo", "baz"))
+			})
+		})
+
+		Context("when a patch targets an object which is not rendered", func() {
+			It("should fail", func() {
+				patchConf := conf.DeepCopy()
+				patchConf.Patches = []opv1.Patch{
+					{
+						Target: opv1.PatchTarget{APIVersion: "apps/v1", Kind: "DaemonSet", Namespace: "ns", Name: "kube-multus-ds-amd64"},
+						Type:   opv1.PatchTypeStrategicMerge,
+						Patch:  "{}",
+					},
+				}
+
+				_, err := ApplyPatches(patchConf, objs)
+				Expect(err).To(MatchError(ContainSubstring("patches[0] targets (apps/v1, Kind=DaemonSet) ns/kube-multus-ds-amd64, which is not rendered")))
+			})
+		})
+
+		Context("when a patch changes name of the object", func() {
+			It("should fail", func() {
+				patchConf := conf.DeepCopy()
+				patchConf.Patches = []opv1.Patch{
+					{
+						Target: ovsTarget(objs),
+						Type:   opv1.PatchTypeStrategicMerge,
+						Patch:  "metadata:\n  name: foo\n",
+					},
+				}
+
+				_, err := ApplyPatches(patchConf, objs)
+				Expect(err).To(MatchError(ContainSubstring("patch must not change identity of the object")))
+			})
+		})
+	})
+
+	Describe("AppliedPatches", func() {
+		It("should list targets and types of patches", func() {
+			target := opv1.PatchTarget{APIVersion: "v1", Kind: "Namespace", Name: "ns"}
+			conf := &opv1.NetworkAddonsConfigSpec{
+				Patches: []opv1.Patch{{Target: target, Type: opv1.PatchTypeJSON, Patch: "[]"}},
+			}
+			Expect(AppliedPatches(conf)).To(Equal([]opv1.AppliedPatch{{Target: target, Type: opv1.PatchTypeJSON}}))
+		})
+	})
+})
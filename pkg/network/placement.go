@@ -0,0 +1,392 @@
+package network
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+const (
+	archLabel        = "kubernetes.io/arch"
+	masterTaintLabel = "node-role.kubernetes.io/master"
+)
+
+// Architecture of images which do not list their supported architectures
+const defaultArchitecture = "amd64"
+
+// defaultNodePlacement is used by DaemonSets of CNI plugins and node agents, they run on all nodes
+// including masters
+func defaultNodePlacement() *opv1.Placement {
+	return &opv1.Placement{
+		Tolerations: []v1.Toleration{
+			{
+				Key:      masterTaintLabel,
+				Operator: v1.TolerationOpExists,
+				Effect:   v1.TaintEffectNoSchedule,
+			},
+		},
+	}
+}
+
+// defaultMultusPlacement tolerates all NoSchedule taints, since Multus is needed by every pod
+// requesting secondary networks
+func defaultMultusPlacement() *opv1.Placement {
+	return &opv1.Placement{
+		Tolerations: []v1.Toleration{
+			{
+				Operator: v1.TolerationOpExists,
+				Effect:   v1.TaintEffectNoSchedule,
+			},
+		},
+	}
+}
+
+// defaultKubeMacPoolPlacement spreads replicas of the KubeMacPool manager over different nodes
+func defaultKubeMacPoolPlacement() *opv1.Placement {
+	return &opv1.Placement{
+		Affinity: &v1.Affinity{
+			PodAntiAffinity: &v1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
+					{
+						Weight: 1,
+						PodAffinityTerm: v1.PodAffinityTerm{
+							LabelSelector: &metav1.LabelSelector{
+								MatchExpressions: []metav1.LabelSelectorRequirement{
+									{
+										Key:      "control-plane",
+										Operator: metav1.LabelSelectorOpIn,
+										Values:   []string{"mac-controller-manager"},
+									},
+								},
+							},
+							TopologyKey: "kubernetes.io/hostname",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// effectivePlacement merges placements of a component, each field set in a later placement
+// replaces the respective field of the earlier ones. Usually called with the default placement of
+// the component, the global placement and the placement of the component, in this order
+func effectivePlacement(placements ...*opv1.Placement) *opv1.Placement {
+	effective := &opv1.Placement{}
+	for _, placement := range placements {
+		if placement == nil {
+			continue
+		}
+		if len(placement.NodeSelector) > 0 {
+			effective.NodeSelector = placement.NodeSelector
+		}
+		if placement.Affinity != nil {
+			effective.Affinity = placement.Affinity
+		}
+		if len(placement.Tolerations) > 0 {
+			effective.Tolerations = placement.Tolerations
+		}
+	}
+	return effective.DeepCopy()
+}
+
+// supportedArchitectures returns architectures supported by all images of a component, images
+// are given by names of environment variables holding them. Architectures of an image are listed
+// in a comma separated <IMAGE>_ARCHITECTURES variable, only amd64 is assumed if it is not set
+func supportedArchitectures(imageEnvs ...string) ([]string, error) {
+	var supported []string
+	for _, imageEnv := range imageEnvs {
+		architectures := []string{}
+		for _, architecture := range strings.Split(os.Getenv(imageEnv+"_ARCHITECTURES"), ",") {
+			if architecture = strings.TrimSpace(architecture); architecture != "" {
+				architectures = append(architectures, architecture)
+			}
+		}
+		if len(architectures) == 0 {
+			architectures = []string{defaultArchitecture}
+		}
+
+		if supported == nil {
+			supported = architectures
+			continue
+		}
+		common := []string{}
+		for _, architecture := range supported {
+			if containsString(architectures, architecture) {
+				common = append(common, architecture)
+			}
+		}
+		supported = common
+	}
+
+	if len(supported) == 0 {
+		return nil, errors.Errorf("images %s have no architecture in common", strings.Join(imageEnvs, ", "))
+	}
+	return supported, nil
+}
+
+// restrictToArchitectures limits placement to nodes of given architectures. The requirement is
+// added to each required node selector term, so it applies no matter which of them is matched
+func restrictToArchitectures(placement *opv1.Placement, architectures []string) *opv1.Placement {
+	requirement := v1.NodeSelectorRequirement{
+		Key:      archLabel,
+		Operator: v1.NodeSelectorOpIn,
+		Values:   architectures,
+	}
+
+	if placement.Affinity == nil {
+		placement.Affinity = &v1.Affinity{}
+	}
+	if placement.Affinity.NodeAffinity == nil {
+		placement.Affinity.NodeAffinity = &v1.NodeAffinity{}
+	}
+	nodeAffinity := placement.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &v1.NodeSelector{}
+	}
+	required := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(required.NodeSelectorTerms) == 0 {
+		required.NodeSelectorTerms = []v1.NodeSelectorTerm{{}}
+	}
+	for i := range required.NodeSelectorTerms {
+		term := &required.NodeSelectorTerms[i]
+		term.MatchExpressions = append(term.MatchExpressions, requirement)
+	}
+
+	return placement
+}
+
+// componentImage is an image of a component as configured in the config, if overridden, together
+// with the environment variable holding its default
+type componentImage struct {
+	configured string
+	defaultEnv string
+}
+
+// componentPlacement returns effective placement of a component restricted to architectures
+// supported by all its default images. Architectures of images overridden in the config are not
+// known, placing them on nodes of a supported architecture is up to the user
+func componentPlacement(defaults, global, component *opv1.Placement, images ...componentImage) (*opv1.Placement, error) {
+	placement := effectivePlacement(defaults, global, component)
+
+	defaultImageEnvs := []string{}
+	for _, image := range images {
+		if image.configured == "" {
+			defaultImageEnvs = append(defaultImageEnvs, image.defaultEnv)
+		}
+	}
+	if len(defaultImageEnvs) == 0 {
+		return placement, nil
+	}
+
+	architectures, err := supportedArchitectures(defaultImageEnvs...)
+	if err != nil {
+		return nil, err
+	}
+	return restrictToArchitectures(placement, architectures), nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func validatePlacements(conf *opv1.NetworkAddonsConfigSpec) []error {
+	errs := []error{}
+
+	errs = append(errs, validatePlacement("placement", conf.Placement)...)
+	if conf.Multus != nil {
+		errs = append(errs, validatePlacement("multus.placement", conf.Multus.Placement)...)
+	}
+	if conf.LinuxBridge != nil {
+		errs = append(errs, validatePlacement("linuxBridge.placement", conf.LinuxBridge.Placement)...)
+	}
+	if conf.Ovs != nil {
+		errs = append(errs, validatePlacement("ovs.placement", conf.Ovs.Placement)...)
+	}
+	if conf.KubeMacPool != nil {
+		errs = append(errs, validatePlacement("kubeMacPool.placement", conf.KubeMacPool.Placement)...)
+	}
+	if conf.NMState != nil {
+		errs = append(errs, validatePlacement("nmstate.placement", conf.NMState.Placement)...)
+	}
+
+	return errs
+}
+
+func validatePlacement(path string, placement *opv1.Placement) []error {
+	if placement == nil {
+		return []error{}
+	}
+
+	errs := []error{}
+
+	for key, value := range placement.NodeSelector {
+		for _, msg := range validation.IsQualifiedName(key) {
+			errs = append(errs, errors.Errorf("%s.nodeSelector key '%s' is not valid: %s", path, key, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(value) {
+			errs = append(errs, errors.Errorf("%s.nodeSelector value '%s' is not valid: %s", path, value, msg))
+		}
+	}
+
+	for i, toleration := range placement.Tolerations {
+		errs = append(errs, validateToleration(path+".tolerations["+strconv.Itoa(i)+"]", toleration)...)
+	}
+
+	if placement.Affinity != nil {
+		errs = append(errs, validateAffinity(path+".affinity", placement.Affinity)...)
+	}
+
+	return errs
+}
+
+func validateToleration(path string, toleration v1.Toleration) []error {
+	errs := []error{}
+
+	if toleration.Key != "" {
+		for _, msg := range validation.IsQualifiedName(toleration.Key) {
+			errs = append(errs, errors.Errorf("%s key '%s' is not valid: %s", path, toleration.Key, msg))
+		}
+	}
+
+	switch toleration.Operator {
+	case v1.TolerationOpEqual, "":
+		if toleration.Key == "" {
+			errs = append(errs, errors.Errorf("%s operator must be Exists when key is empty", path))
+		}
+		for _, msg := range validation.IsValidLabelValue(toleration.Value) {
+			errs = append(errs, errors.Errorf("%s value '%s' is not valid: %s", path, toleration.Value, msg))
+		}
+	case v1.TolerationOpExists:
+		if toleration.Value != "" {
+			errs = append(errs, errors.Errorf("%s value must be empty when operator is Exists", path))
+		}
+	default:
+		errs = append(errs, errors.Errorf("%s operator '%s' is not valid", path, toleration.Operator))
+	}
+
+	switch toleration.Effect {
+	case v1.TaintEffectNoSchedule, v1.TaintEffectPreferNoSchedule, v1.TaintEffectNoExecute, "":
+	default:
+		errs = append(errs, errors.Errorf("%s effect '%s' is not valid", path, toleration.Effect))
+	}
+
+	if toleration.TolerationSeconds != nil && toleration.Effect != v1.TaintEffectNoExecute {
+		errs = append(errs, errors.Errorf("%s tolerationSeconds can be set only with NoExecute effect", path))
+	}
+
+	return errs
+}
+
+func validateAffinity(path string, affinity *v1.Affinity) []error {
+	errs := []error{}
+
+	if nodeAffinity := affinity.NodeAffinity; nodeAffinity != nil {
+		if required := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+			if len(required.NodeSelectorTerms) == 0 {
+				errs = append(errs, errors.Errorf("%s.nodeAffinity must contain at least one required node selector term", path))
+			}
+			for _, term := range required.NodeSelectorTerms {
+				errs = append(errs, validateNodeSelectorTerm(path+".nodeAffinity", term)...)
+			}
+		}
+		for _, preferred := range nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+			errs = append(errs, validateWeight(path+".nodeAffinity", preferred.Weight)...)
+			errs = append(errs, validateNodeSelectorTerm(path+".nodeAffinity", preferred.Preference)...)
+		}
+	}
+
+	if podAffinity := affinity.PodAffinity; podAffinity != nil {
+		errs = append(errs, validatePodAffinityTerms(path+".podAffinity", podAffinity.RequiredDuringSchedulingIgnoredDuringExecution, podAffinity.PreferredDuringSchedulingIgnoredDuringExecution)...)
+	}
+
+	if podAntiAffinity := affinity.PodAntiAffinity; podAntiAffinity != nil {
+		errs = append(errs, validatePodAffinityTerms(path+".podAntiAffinity", podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)...)
+	}
+
+	return errs
+}
+
+func validateNodeSelectorTerm(path string, term v1.NodeSelectorTerm) []error {
+	errs := []error{}
+
+	for _, requirements := range [][]v1.NodeSelectorRequirement{term.MatchExpressions, term.MatchFields} {
+		for _, requirement := range requirements {
+			for _, msg := range validation.IsQualifiedName(requirement.Key) {
+				errs = append(errs, errors.Errorf("%s key '%s' is not valid: %s", path, requirement.Key, msg))
+			}
+
+			switch requirement.Operator {
+			case v1.NodeSelectorOpIn, v1.NodeSelectorOpNotIn:
+				if len(requirement.Values) == 0 {
+					errs = append(errs, errors.Errorf("%s values must be set for operator %s", path, requirement.Operator))
+				}
+			case v1.NodeSelectorOpExists, v1.NodeSelectorOpDoesNotExist:
+				if len(requirement.Values) > 0 {
+					errs = append(errs, errors.Errorf("%s values must be empty for operator %s", path, requirement.Operator))
+				}
+			case v1.NodeSelectorOpGt, v1.NodeSelectorOpLt:
+				if len(requirement.Values) != 1 {
+					errs = append(errs, errors.Errorf("%s exactly one value must be set for operator %s", path, requirement.Operator))
+				} else if _, err := strconv.ParseInt(requirement.Values[0], 10, 64); err != nil {
+					errs = append(errs, errors.Errorf("%s value of operator %s must be an integer", path, requirement.Operator))
+				}
+			default:
+				errs = append(errs, errors.Errorf("%s operator '%s' is not valid", path, requirement.Operator))
+			}
+		}
+	}
+
+	return errs
+}
+
+func validatePodAffinityTerms(path string, required []v1.PodAffinityTerm, preferred []v1.WeightedPodAffinityTerm) []error {
+	errs := []error{}
+
+	for _, term := range required {
+		errs = append(errs, validatePodAffinityTerm(path, term)...)
+	}
+	for _, term := range preferred {
+		errs = append(errs, validateWeight(path, term.Weight)...)
+		errs = append(errs, validatePodAffinityTerm(path, term.PodAffinityTerm)...)
+	}
+
+	return errs
+}
+
+func validatePodAffinityTerm(path string, term v1.PodAffinityTerm) []error {
+	errs := []error{}
+
+	if term.TopologyKey == "" {
+		errs = append(errs, errors.Errorf("%s topologyKey must be set", path))
+	} else {
+		for _, msg := range validation.IsQualifiedName(term.TopologyKey) {
+			errs = append(errs, errors.Errorf("%s topologyKey '%s' is not valid: %s", path, term.TopologyKey, msg))
+		}
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(term.LabelSelector); err != nil {
+		errs = append(errs, errors.Errorf("%s labelSelector is not valid: %v", path, err))
+	}
+
+	return errs
+}
+
+func validateWeight(path string, weight int32) []error {
+	if weight < 1 || weight > 100 {
+		return []error{errors.Errorf("%s weight %d must be in the range 1-100", path, weight)}
+	}
+	return []error{}
+}
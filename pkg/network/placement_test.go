@@ -0,0 +1,330 @@
+package network
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+var _ = Describe("Testing placement", func() {
+	infraToleration := v1.Toleration{Key: "node-role.kubernetes.io/infra", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule}
+	storageToleration := v1.Toleration{Key: "node-role.kubernetes.io/storage", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule}
+
+	Describe("effectivePlacement", func() {
+		Context("when nothing is configured", func() {
+			It("should return the defaults", func() {
+				Expect(effectivePlacement(defaultNodePlacement(), nil, nil)).To(Equal(defaultNodePlacement()))
+			})
+		})
+
+		Context("when global placement is configured", func() {
+			global := &opv1.Placement{Tolerations: []v1.Toleration{infraToleration}}
+
+			It("should replace only configured fields of the defaults", func() {
+				placement := effectivePlacement(defaultKubeMacPoolPlacement(), global, nil)
+				Expect(placement.Affinity).To(Equal(defaultKubeMacPoolPlacement().Affinity))
+				Expect(placement.Tolerations).To(ConsistOf(infraToleration))
+			})
+
+			Context("and component placement is configured too", func() {
+				component := &opv1.Placement{
+					NodeSelector: map[string]string{"network": "secondary"},
+					Tolerations:  []v1.Toleration{storageToleration},
+				}
+
+				It("should prefer the component placement", func() {
+					placement := effectivePlacement(defaultNodePlacement(), global, component)
+					Expect(placement.NodeSelector).To(Equal(map[string]string{"network": "secondary"}))
+					Expect(placement.Tolerations).To(ConsistOf(storageToleration))
+				})
+			})
+		})
+	})
+
+	Describe("validatePlacements", func() {
+		Context("when placements are valid", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{
+				Placement: &opv1.Placement{Tolerations: []v1.Toleration{infraToleration}},
+				Multus:    &opv1.Multus{Placement: defaultMultusPlacement()},
+				KubeMacPool: &opv1.KubeMacPool{
+					Placement: defaultKubeMacPoolPlacement(),
+				},
+			}
+
+			It("should pass", func() {
+				Expect(validatePlacements(conf)).To(BeEmpty())
+			})
+		})
+
+		Context("when component placement is invalid", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{
+				Ovs: &opv1.Ovs{
+					Placement: &opv1.Placement{
+						NodeSelector: map[string]string{"invalid key!": "value"},
+						Tolerations: []v1.Toleration{
+							{Operator: v1.TolerationOpEqual, Value: "foo"},
+							{Key: "foo", Operator: v1.TolerationOpExists, Effect: v1.TaintEffect("bar")},
+						},
+						Affinity: &v1.Affinity{
+							NodeAffinity: &v1.NodeAffinity{
+								RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+									NodeSelectorTerms: []v1.NodeSelectorTerm{
+										{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "foo", Operator: v1.NodeSelectorOpIn}}},
+									},
+								},
+							},
+							PodAntiAffinity: &v1.PodAntiAffinity{
+								RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{{}},
+							},
+						},
+					},
+				},
+			}
+
+			It("should report all errors with path of the invalid field", func() {
+				errs := validatePlacements(conf)
+				Expect(errs).To(HaveLen(5))
+				Expect(errs).To(ContainElement(MatchError(ContainSubstring("ovs.placement.nodeSelector key 'invalid key!' is not valid"))))
+				Expect(errs).To(ContainElement(MatchError("ovs.placement.tolerations[0] operator must be Exists when key is empty")))
+				Expect(errs).To(ContainElement(MatchError("ovs.placement.tolerations[1] effect 'bar' is not valid")))
+				Expect(errs).To(ContainElement(MatchError("ovs.placement.affinity.nodeAffinity values must be set for operator In")))
+				Expect(errs).To(ContainElement(MatchError("ovs.placement.affinity.podAntiAffinity topologyKey must be set")))
+			})
+		})
+	})
+
+	Describe("IsChangeSafe", func() {
+		Context("when only placement of a deployed component is changed", func() {
+			prev := &opv1.NetworkAddonsConfigSpec{LinuxBridge: &opv1.LinuxBridge{}}
+			next := &opv1.NetworkAddonsConfigSpec{
+				LinuxBridge: &opv1.LinuxBridge{Placement: &opv1.Placement{Tolerations: []v1.Toleration{infraToleration}}},
+				Placement:   &opv1.Placement{NodeSelector: map[string]string{"network": "secondary"}},
+			}
+
+			It("should pass the check", func() {
+				Expect(IsChangeSafe(prev, next)).To(Succeed())
+			})
+		})
+	})
+
+	Describe("supportedArchitectures", func() {
+		AfterEach(func() {
+			os.Unsetenv("FOO_IMAGE_ARCHITECTURES")
+			os.Unsetenv("BAR_IMAGE_ARCHITECTURES")
+		})
+
+		Context("when architectures of the image are not listed", func() {
+			It("should assume amd64", func() {
+				Expect(supportedArchitectures("FOO_IMAGE")).To(Equal([]string{"amd64"}))
+			})
+		})
+
+		Context("when component has multiple images", func() {
+			BeforeEach(func() {
+				os.Setenv("FOO_IMAGE_ARCHITECTURES", "amd64, arm64,ppc64le")
+				os.Setenv("BAR_IMAGE_ARCHITECTURES", "ppc64le,amd64")
+			})
+
+			It("should return architectures supported by all of them", func() {
+				Expect(supportedArchitectures("FOO_IMAGE", "BAR_IMAGE")).To(Equal([]string{"amd64", "ppc64le"}))
+			})
+		})
+
+		Context("when images have no architecture in common", func() {
+			BeforeEach(func() {
+				os.Setenv("FOO_IMAGE_ARCHITECTURES", "arm64")
+			})
+
+			It("should fail", func() {
+				_, err := supportedArchitectures("FOO_IMAGE", "BAR_IMAGE")
+				Expect(err).To(MatchError("images FOO_IMAGE, BAR_IMAGE have no architecture in common"))
+			})
+		})
+	})
+
+	Describe("restrictToArchitectures", func() {
+		archRequirement := v1.NodeSelectorRequirement{Key: "kubernetes.io/arch", Operator: v1.NodeSelectorOpIn, Values: []string{"amd64", "arm64"}}
+
+		Context("when placement has no node affinity", func() {
+			It("should add a required node selector term", func() {
+				placement := restrictToArchitectures(defaultKubeMacPoolPlacement(), []string{"amd64", "arm64"})
+				Expect(placement.Affinity.PodAntiAffinity).To(Equal(defaultKubeMacPoolPlacement().Affinity.PodAntiAffinity))
+				Expect(placement.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(Equal(
+					[]v1.NodeSelectorTerm{{MatchExpressions: []v1.NodeSelectorRequirement{archRequirement}}},
+				))
+			})
+		})
+
+		Context("when placement has multiple required node selector terms", func() {
+			networkRequirement := v1.NodeSelectorRequirement{Key: "network", Operator: v1.NodeSelectorOpExists}
+			storageRequirement := v1.NodeSelectorRequirement{Key: "storage", Operator: v1.NodeSelectorOpExists}
+			placement := &opv1.Placement{
+				Affinity: &v1.Affinity{
+					NodeAffinity: &v1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+							NodeSelectorTerms: []v1.NodeSelectorTerm{
+								{MatchExpressions: []v1.NodeSelectorRequirement{networkRequirement}},
+								{MatchExpressions: []v1.NodeSelectorRequirement{storageRequirement}},
+							},
+						},
+					},
+				},
+			}
+
+			It("should add the requirement to each of them", func() {
+				restricted := restrictToArchitectures(placement.DeepCopy(), []string{"amd64", "arm64"})
+				Expect(restricted.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(Equal(
+					[]v1.NodeSelectorTerm{
+						{MatchExpressions: []v1.NodeSelectorRequirement{networkRequirement, archRequirement}},
+						{MatchExpressions: []v1.NodeSelectorRequirement{storageRequirement, archRequirement}},
+					},
+				))
+			})
+		})
+	})
+
+	Describe("Render", func() {
+		clusterInfo := &ClusterInfo{SCCAvailable: true, OpenShift4: false}
+		amd64Only := &v1.Affinity{
+			NodeAffinity: &v1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+					NodeSelectorTerms: []v1.NodeSelectorTerm{
+						{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "kubernetes.io/arch", Operator: v1.NodeSelectorOpIn, Values: []string{"amd64"}}}},
+					},
+				},
+			},
+		}
+
+		podSpec := func(objs []*unstructured.Unstructured, kind, name string) v1.PodSpec {
+			for _, obj := range objs {
+				if obj.GetKind() == kind && obj.GetName() == name {
+					spec, found, err := unstructured.NestedMap(obj.Object, "spec", "template", "spec")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					podSpec := v1.PodSpec{}
+					Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(spec, &podSpec)).To(Succeed())
+					return podSpec
+				}
+			}
+			Fail("object " + kind + " " + name + " was not rendered")
+			return v1.PodSpec{}
+		}
+
+		Context("when no placement is configured", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{
+				ImagePullPolicy: v1.PullAlways,
+				Multus:          &opv1.Multus{},
+				LinuxBridge:     &opv1.LinuxBridge{},
+				KubeMacPool:     &opv1.KubeMacPool{RangeStart: "02:00:00:00:00:00", RangeEnd: "02:FF:FF:FF:FF:FF"},
+			}
+
+			It("should use default placement of each component restricted to amd64", func() {
+				objs, err := Render(conf, "../../data", nil, clusterInfo)
+				Expect(err).NotTo(HaveOccurred())
+
+				multus := podSpec(objs, "DaemonSet", "kube-multus-ds")
+				Expect(multus.NodeSelector).To(BeEmpty())
+				Expect(multus.Tolerations).To(Equal(defaultMultusPlacement().Tolerations))
+				Expect(multus.Affinity).To(Equal(amd64Only))
+
+				bridgeMarker := podSpec(objs, "DaemonSet", "bridge-marker")
+				Expect(bridgeMarker.NodeSelector).To(BeEmpty())
+				Expect(bridgeMarker.Tolerations).To(Equal(defaultNodePlacement().Tolerations))
+				Expect(bridgeMarker.Affinity).To(Equal(amd64Only))
+
+				kubeMacPool := podSpec(objs, "Deployment", "kubemacpool-mac-controller-manager")
+				Expect(kubeMacPool.NodeSelector).To(BeEmpty())
+				Expect(kubeMacPool.Affinity.PodAntiAffinity).To(Equal(defaultKubeMacPoolPlacement().Affinity.PodAntiAffinity))
+				Expect(kubeMacPool.Affinity.NodeAffinity).To(Equal(amd64Only.NodeAffinity))
+			})
+		})
+
+		Context("when global and component placement is configured", func() {
+			nodeSelector := map[string]string{"network": "secondary"}
+			conf := &opv1.NetworkAddonsConfigSpec{
+				ImagePullPolicy: v1.PullAlways,
+				Placement:       &opv1.Placement{Tolerations: []v1.Toleration{infraToleration}},
+				Ovs:             &opv1.Ovs{Placement: &opv1.Placement{NodeSelector: nodeSelector}},
+				NMState:         &opv1.NMState{},
+			}
+
+			It("should render the merged placement", func() {
+				objs, err := Render(conf, "../../data", nil, clusterInfo)
+				Expect(err).NotTo(HaveOccurred())
+
+				ovs := podSpec(objs, "DaemonSet", "ovs-cni")
+				Expect(ovs.NodeSelector).To(Equal(nodeSelector))
+				Expect(ovs.Tolerations).To(ConsistOf(infraToleration))
+				Expect(ovs.Affinity).To(Equal(amd64Only))
+
+				nmstate := podSpec(objs, "DaemonSet", "nmstate-handler")
+				Expect(nmstate.NodeSelector).To(BeEmpty())
+				Expect(nmstate.Tolerations).To(ConsistOf(infraToleration))
+			})
+		})
+
+		Context("when images support multiple architectures", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{ImagePullPolicy: v1.PullAlways, Multus: &opv1.Multus{}}
+
+			BeforeEach(func() {
+				os.Setenv("MULTUS_IMAGE_ARCHITECTURES", "amd64,arm64")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("MULTUS_IMAGE_ARCHITECTURES")
+			})
+
+			It("should render a single DaemonSet for all of them", func() {
+				objs, err := Render(conf, "../../data", nil, clusterInfo)
+				Expect(err).NotTo(HaveOccurred())
+
+				multus := podSpec(objs, "DaemonSet", "kube-multus-ds")
+				Expect(multus.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(Equal(
+					[]v1.NodeSelectorTerm{
+						{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "kubernetes.io/arch", Operator: v1.NodeSelectorOpIn, Values: []string{"amd64", "arm64"}}}},
+					},
+				))
+			})
+		})
+
+		Context("when images are overridden", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{
+				ImagePullPolicy: v1.PullAlways,
+				Multus:          &opv1.Multus{Image: "quay.io/example/multus:latest"},
+				LinuxBridge:     &opv1.LinuxBridge{MarkerImage: "quay.io/example/bridge-marker:latest"},
+			}
+
+			BeforeEach(func() {
+				os.Setenv("LINUX_BRIDGE_IMAGE_ARCHITECTURES", "amd64,arm64")
+				os.Setenv("LINUX_BRIDGE_MARKER_IMAGE_ARCHITECTURES", "amd64")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("LINUX_BRIDGE_IMAGE_ARCHITECTURES")
+				os.Unsetenv("LINUX_BRIDGE_MARKER_IMAGE_ARCHITECTURES")
+			})
+
+			It("should restrict placement only by architectures of default images", func() {
+				objs, err := Render(conf, "../../data", nil, clusterInfo)
+				Expect(err).NotTo(HaveOccurred())
+
+				multus := podSpec(objs, "DaemonSet", "kube-multus-ds")
+				Expect(multus.Affinity).To(BeNil())
+
+				linuxBridge := podSpec(objs, "DaemonSet", "bridge-marker")
+				Expect(linuxBridge.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(Equal(
+					[]v1.NodeSelectorTerm{
+						{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "kubernetes.io/arch", Operator: v1.NodeSelectorOpIn, Values: []string{"amd64", "arm64"}}}},
+					},
+				))
+			})
+		})
+	})
+})
@@ -0,0 +1,129 @@
+package network
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+// DefaultProgressDeadline is the time a component may be rolling out before it is reported as
+// Degraded, unless the component configures its own
+const DefaultProgressDeadline = 10 * time.Minute
+
+func validateProgressDeadlines(conf *opv1.NetworkAddonsConfigSpec) []error {
+	errs := []error{}
+
+	if conf.Multus != nil {
+		errs = append(errs, validateProgressDeadline("multus.progressDeadline", conf.Multus.ProgressDeadline)...)
+	}
+	if conf.LinuxBridge != nil {
+		errs = append(errs, validateProgressDeadline("linuxBridge.progressDeadline", conf.LinuxBridge.ProgressDeadline)...)
+	}
+	if conf.Ovs != nil {
+		errs = append(errs, validateProgressDeadline("ovs.progressDeadline", conf.Ovs.ProgressDeadline)...)
+	}
+	if conf.KubeMacPool != nil {
+		errs = append(errs, validateProgressDeadline("kubeMacPool.progressDeadline", conf.KubeMacPool.ProgressDeadline)...)
+	}
+	if conf.NMState != nil {
+		errs = append(errs, validateProgressDeadline("nmstate.progressDeadline", conf.NMState.ProgressDeadline)...)
+	}
+
+	return errs
+}
+
+func validateProgressDeadline(path string, deadline *metav1.Duration) []error {
+	if deadline != nil && deadline.Duration <= 0 {
+		return []error{errors.Errorf("%s '%s' must be positive", path, deadline.Duration)}
+	}
+	return []error{}
+}
+
+// fillDefaultsProgressDeadlines sets deadlines of enabled components which do not configure their
+// own, so the effective deadline is recorded in the applied configuration. Previously applied
+// deadlines are kept, so a change of the default does not affect existing clusters
+func fillDefaultsProgressDeadlines(conf, previous *opv1.NetworkAddonsConfigSpec) []error {
+	if previous == nil {
+		previous = &opv1.NetworkAddonsConfigSpec{}
+	}
+
+	if conf.Multus != nil && conf.Multus.ProgressDeadline == nil {
+		var previousDeadline *metav1.Duration
+		if previous.Multus != nil {
+			previousDeadline = previous.Multus.ProgressDeadline
+		}
+		conf.Multus.ProgressDeadline = defaultProgressDeadline(previousDeadline)
+	}
+	if conf.LinuxBridge != nil && conf.LinuxBridge.ProgressDeadline == nil {
+		var previousDeadline *metav1.Duration
+		if previous.LinuxBridge != nil {
+			previousDeadline = previous.LinuxBridge.ProgressDeadline
+		}
+		conf.LinuxBridge.ProgressDeadline = defaultProgressDeadline(previousDeadline)
+	}
+	if conf.Ovs != nil && conf.Ovs.ProgressDeadline == nil {
+		var previousDeadline *metav1.Duration
+		if previous.Ovs != nil {
+			previousDeadline = previous.Ovs.ProgressDeadline
+		}
+		conf.Ovs.ProgressDeadline = defaultProgressDeadline(previousDeadline)
+	}
+	if conf.KubeMacPool != nil && conf.KubeMacPool.ProgressDeadline == nil {
+		var previousDeadline *metav1.Duration
+		if previous.KubeMacPool != nil {
+			previousDeadline = previous.KubeMacPool.ProgressDeadline
+		}
+		conf.KubeMacPool.ProgressDeadline = defaultProgressDeadline(previousDeadline)
+	}
+	if conf.NMState != nil && conf.NMState.ProgressDeadline == nil {
+		var previousDeadline *metav1.Duration
+		if previous.NMState != nil {
+			previousDeadline = previous.NMState.ProgressDeadline
+		}
+		conf.NMState.ProgressDeadline = defaultProgressDeadline(previousDeadline)
+	}
+
+	return []error{}
+}
+
+// defaultProgressDeadline returns the previously applied deadline of a component, if there is one,
+// or DefaultProgressDeadline otherwise
+func defaultProgressDeadline(previous *metav1.Duration) *metav1.Duration {
+	if previous != nil {
+		return previous.DeepCopy()
+	}
+	return &metav1.Duration{Duration: DefaultProgressDeadline}
+}
+
+// ProgressDeadlines returns progress deadlines of all enabled components, keyed by component name
+func ProgressDeadlines(conf *opv1.NetworkAddonsConfigSpec) map[string]time.Duration {
+	deadlines := map[string]time.Duration{}
+
+	if conf.Multus != nil {
+		deadlines[MultusComponent] = progressDeadline(conf.Multus.ProgressDeadline)
+	}
+	if conf.LinuxBridge != nil {
+		deadlines[LinuxBridgeComponent] = progressDeadline(conf.LinuxBridge.ProgressDeadline)
+	}
+	if conf.Ovs != nil {
+		deadlines[OvsComponent] = progressDeadline(conf.Ovs.ProgressDeadline)
+	}
+	if conf.KubeMacPool != nil {
+		deadlines[KubeMacPoolComponent] = progressDeadline(conf.KubeMacPool.ProgressDeadline)
+	}
+	if conf.NMState != nil {
+		deadlines[NMStateComponent] = progressDeadline(conf.NMState.ProgressDeadline)
+	}
+
+	return deadlines
+}
+
+func progressDeadline(configured *metav1.Duration) time.Duration {
+	if configured != nil {
+		return configured.Duration
+	}
+	return DefaultProgressDeadline
+}
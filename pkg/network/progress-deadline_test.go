@@ -0,0 +1,66 @@
+package network
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+var _ = Describe("Testing progress deadlines", func() {
+	Describe("validateProgressDeadlines", func() {
+		Context("when a deadline is not positive", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{
+				Ovs: &opv1.Ovs{ProgressDeadline: &metav1.Duration{Duration: -time.Minute}},
+			}
+
+			It("should report path of the invalid field", func() {
+				Expect(validateProgressDeadlines(conf)).To(ConsistOf(MatchError(ContainSubstring("ovs.progressDeadline '-1m0s' must be positive"))))
+			})
+		})
+	})
+
+	Describe("fillDefaultsProgressDeadlines", func() {
+		Context("when a deployed component has no deadline configured", func() {
+			previous := &opv1.NetworkAddonsConfigSpec{Ovs: &opv1.Ovs{ProgressDeadline: &metav1.Duration{Duration: 20 * time.Minute}}}
+			conf := &opv1.NetworkAddonsConfigSpec{Ovs: &opv1.Ovs{}, Multus: &opv1.Multus{}}
+
+			It("should keep its previous deadline and default the others", func() {
+				Expect(fillDefaultsProgressDeadlines(conf, previous)).To(BeEmpty())
+				Expect(conf.Ovs.ProgressDeadline.Duration).To(Equal(20 * time.Minute))
+				Expect(conf.Multus.ProgressDeadline.Duration).To(Equal(DefaultProgressDeadline))
+			})
+		})
+	})
+
+	Describe("IsChangeSafe", func() {
+		Context("when progress deadline of a deployed component is changed", func() {
+			prev := &opv1.NetworkAddonsConfigSpec{LinuxBridge: &opv1.LinuxBridge{ProgressDeadline: &metav1.Duration{Duration: 10 * time.Minute}}}
+			next := &opv1.NetworkAddonsConfigSpec{LinuxBridge: &opv1.LinuxBridge{ProgressDeadline: &metav1.Duration{Duration: 30 * time.Minute}}}
+
+			It("should pass the check", func() {
+				Expect(IsChangeSafe(prev, next)).To(Succeed())
+			})
+		})
+	})
+
+	Describe("ProgressDeadlines", func() {
+		Context("when only some components configure their deadline", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{
+				Multus:      &opv1.Multus{},
+				KubeMacPool: &opv1.KubeMacPool{ProgressDeadline: &metav1.Duration{Duration: 30 * time.Minute}},
+			}
+
+			It("should default the others and skip disabled components", func() {
+				Expect(ProgressDeadlines(conf)).To(Equal(map[string]time.Duration{
+					MultusComponent:      DefaultProgressDeadline,
+					KubeMacPoolComponent: 30 * time.Minute,
+				}))
+			})
+		})
+	})
+})
@@ -0,0 +1,147 @@
+package network
+
+import (
+	"log"
+
+	osv1 "github.com/openshift/api/operator/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
+)
+
+// RemovedComponents lists components which were deployed according to the previous configuration,
+// but are not requested by the current one anymore
+func RemovedComponents(prev, conf *opv1.NetworkAddonsConfigSpec) []string {
+	removed := []string{}
+	if prev == nil {
+		return removed
+	}
+
+	if prev.Multus != nil && conf.Multus == nil {
+		removed = append(removed, MultusComponent)
+	}
+	if prev.LinuxBridge != nil && conf.LinuxBridge == nil {
+		removed = append(removed, LinuxBridgeComponent)
+	}
+	if prev.KubeMacPool != nil && conf.KubeMacPool == nil {
+		removed = append(removed, KubeMacPoolComponent)
+	}
+	if prev.NMState != nil && conf.NMState == nil {
+		removed = append(removed, NMStateComponent)
+	}
+	if prev.Ovs != nil && conf.Ovs == nil {
+		removed = append(removed, OvsComponent)
+	}
+	if prev.Monitoring != nil && conf.Monitoring == nil {
+		removed = append(removed, MonitoringComponent)
+	}
+
+	return removed
+}
+
+// RenderRemoved returns objects of components which were removed from the configuration. They are
+// looked up in the inventory of applied objects, under the names they were applied with, even if
+// templates of the current version name them differently. Configurations applied by versions of
+// the operator which did not record the inventory are rendered again instead. Objects which are
+// still rendered for the remaining components (passed in objs), e.g. their shared namespace, are
+// left out, so they are not touched by the removal
+func RenderRemoved(prev, conf *opv1.NetworkAddonsConfigSpec, inventory []apply.InventoryItem, objs []*unstructured.Unstructured, manifestDir string, openshiftNetworkConfig *osv1.Network, clusterInfo *ClusterInfo) ([]*unstructured.Unstructured, error) {
+	removedComponents := RemovedComponents(prev, conf)
+	if len(removedComponents) == 0 {
+		return nil, nil
+	}
+
+	var removedObjs []*unstructured.Unstructured
+	if inventory != nil {
+		removedObjs = inventoryOfComponents(inventory, removedComponents)
+	} else {
+		var err error
+		removedObjs, err = renderRemovedComponents(prev, conf, manifestDir, openshiftNetworkConfig, clusterInfo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rendered := map[string]bool{}
+	for _, obj := range objs {
+		rendered[apply.ObjectKey(obj)] = true
+	}
+
+	removed := []*unstructured.Unstructured{}
+	for _, obj := range removedObjs {
+		if rendered[apply.ObjectKey(obj)] {
+			log.Printf("keeping (%s) %s/%s, it is still rendered by remaining components", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
+			continue
+		}
+		removed = append(removed, obj)
+	}
+
+	return removed, nil
+}
+
+// Inventory lists identities of objects to be applied together with components they were rendered
+// for. Objects are matched with rendered components by their identity, so objects renamed by
+// patches are not associated with any component
+func Inventory(components []RenderedComponent, objs []*unstructured.Unstructured) []apply.InventoryItem {
+	componentsOf := map[string][]string{}
+	for _, component := range components {
+		for _, obj := range component.Objects {
+			key := apply.ObjectKey(obj)
+			componentsOf[key] = append(componentsOf[key], component.Name)
+		}
+	}
+
+	inventory := apply.Inventory(objs)
+	for i, obj := range objs {
+		inventory[i].Components = componentsOf[apply.ObjectKey(obj)]
+	}
+	return inventory
+}
+
+// inventoryOfComponents returns objects recorded in the inventory for any of the given components.
+// Returned objects carry only their identity, which is sufficient to delete them
+func inventoryOfComponents(inventory []apply.InventoryItem, components []string) []*unstructured.Unstructured {
+	wanted := map[string]bool{}
+	for _, component := range components {
+		wanted[component] = true
+	}
+
+	objs := []*unstructured.Unstructured{}
+	for _, item := range inventory {
+		for _, component := range item.Components {
+			if wanted[component] {
+				objs = append(objs, item.Object())
+				break
+			}
+		}
+	}
+	return objs
+}
+
+// renderRemovedComponents renders objects of removed components as they were rendered based on the
+// previous configuration
+func renderRemovedComponents(prev, conf *opv1.NetworkAddonsConfigSpec, manifestDir string, openshiftNetworkConfig *osv1.Network, clusterInfo *ClusterInfo) ([]*unstructured.Unstructured, error) {
+	// Keep only removed components in the previous configuration, global options stay as they were
+	removedConf := prev.DeepCopy()
+	if conf.Multus != nil {
+		removedConf.Multus = nil
+	}
+	if conf.LinuxBridge != nil {
+		removedConf.LinuxBridge = nil
+	}
+	if conf.KubeMacPool != nil {
+		removedConf.KubeMacPool = nil
+	}
+	if conf.NMState != nil {
+		removedConf.NMState = nil
+	}
+	if conf.Ovs != nil {
+		removedConf.Ovs = nil
+	}
+	if conf.Monitoring != nil {
+		removedConf.Monitoring = nil
+	}
+
+	return Render(removedConf, manifestDir, openshiftNetworkConfig, clusterInfo)
+}
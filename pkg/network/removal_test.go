@@ -0,0 +1,175 @@
+package network
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
+)
+
+var _ = Describe("Testing removal of components", func() {
+	Describe("RemovedComponents", func() {
+		Context("when there is no previous configuration", func() {
+			It("should report nothing", func() {
+				Expect(RemovedComponents(nil, &opv1.NetworkAddonsConfigSpec{})).To(BeEmpty())
+			})
+		})
+
+		Context("when some components are dropped and some added", func() {
+			prev := &opv1.NetworkAddonsConfigSpec{Multus: &opv1.Multus{}, Ovs: &opv1.Ovs{}, NMState: &opv1.NMState{}}
+			conf := &opv1.NetworkAddonsConfigSpec{Multus: &opv1.Multus{}, LinuxBridge: &opv1.LinuxBridge{}}
+
+			It("should report only the dropped ones", func() {
+				Expect(RemovedComponents(prev, conf)).To(Equal([]string{"NMState", "Ovs"}))
+			})
+		})
+	})
+
+	Describe("IsChangeSafe", func() {
+		Context("when a component is removed", func() {
+			prev := &opv1.NetworkAddonsConfigSpec{
+				ImagePullPolicy: v1.PullAlways,
+				KubeMacPool:     &opv1.KubeMacPool{RangeStart: "02:00:00:00:00:00", RangeEnd: "02:FF:FF:FF:FF:FF"},
+				Ovs:             &opv1.Ovs{},
+			}
+			next := &opv1.NetworkAddonsConfigSpec{ImagePullPolicy: v1.PullAlways, Ovs: &opv1.Ovs{}}
+
+			It("should pass the check", func() {
+				Expect(IsChangeSafe(prev, next)).To(Succeed())
+			})
+		})
+
+		Context("when a component is removed and another one modified", func() {
+			prev := &opv1.NetworkAddonsConfigSpec{
+				KubeMacPool: &opv1.KubeMacPool{RangeStart: "02:00:00:00:00:00", RangeEnd: "02:FF:FF:FF:FF:FF"},
+				Ovs:         &opv1.Ovs{},
+			}
+			next := &opv1.NetworkAddonsConfigSpec{
+				KubeMacPool: &opv1.KubeMacPool{RangeStart: "02:00:00:00:00:00", RangeEnd: "02:00:00:00:FF:FF"},
+			}
+
+			It("should reject the modification", func() {
+				Expect(IsChangeSafe(prev, next)).To(MatchError(ContainSubstring("cannot modify KubeMacPool configuration once it is deployed")))
+			})
+		})
+	})
+
+	Describe("Inventory", func() {
+		clusterInfo := &ClusterInfo{SCCAvailable: true, OpenShift4: false}
+		conf := &opv1.NetworkAddonsConfigSpec{ImagePullPolicy: v1.PullAlways, Ovs: &opv1.Ovs{}, NMState: &opv1.NMState{}}
+
+		BeforeEach(func() {
+			os.Setenv("OPERAND_NAMESPACE", "cluster-network-addons")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("OPERAND_NAMESPACE")
+		})
+
+		It("should record components each object was rendered for", func() {
+			components, err := RenderComponents(conf, "../../data", nil, clusterInfo)
+			Expect(err).NotTo(HaveOccurred())
+			objs, err := Render(conf, "../../data", nil, clusterInfo)
+			Expect(err).NotTo(HaveOccurred())
+
+			componentsOf := map[string][]string{}
+			for _, item := range Inventory(components, objs) {
+				componentsOf[item.Kind+" "+item.Name] = item.Components
+			}
+			Expect(componentsOf).To(HaveKeyWithValue("DaemonSet ovs-cni", []string{OvsComponent}))
+			Expect(componentsOf).To(HaveKeyWithValue("DaemonSet nmstate-handler", []string{NMStateComponent}))
+			Expect(componentsOf).To(HaveKeyWithValue("Namespace cluster-network-addons", ConsistOf(OvsComponent, NMStateComponent)))
+		})
+	})
+
+	Describe("RenderRemoved", func() {
+		clusterInfo := &ClusterInfo{SCCAvailable: true, OpenShift4: false}
+
+		keys := func(objs []*unstructured.Unstructured) []string {
+			keys := []string{}
+			for _, obj := range objs {
+				keys = append(keys, obj.GetKind()+" "+obj.GetName())
+			}
+			return keys
+		}
+
+		BeforeEach(func() {
+			os.Setenv("OPERAND_NAMESPACE", "cluster-network-addons")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("OPERAND_NAMESPACE")
+		})
+
+		Context("when nothing was removed", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{ImagePullPolicy: v1.PullAlways, Ovs: &opv1.Ovs{}}
+
+			It("should render nothing", func() {
+				objs, err := RenderRemoved(conf, conf, nil, nil, "../../data", nil, clusterInfo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(objs).To(BeEmpty())
+			})
+		})
+
+		Context("when a component was removed", func() {
+			prev := &opv1.NetworkAddonsConfigSpec{ImagePullPolicy: v1.PullAlways, Ovs: &opv1.Ovs{}, NMState: &opv1.NMState{}}
+			conf := &opv1.NetworkAddonsConfigSpec{ImagePullPolicy: v1.PullAlways, NMState: &opv1.NMState{}}
+
+			It("should render objects of the removed component only", func() {
+				objs, err := Render(conf, "../../data", nil, clusterInfo)
+				Expect(err).NotTo(HaveOccurred())
+
+				removed, err := RenderRemoved(prev, conf, nil, objs, "../../data", nil, clusterInfo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys(removed)).To(ContainElement("DaemonSet ovs-cni"))
+				Expect(keys(removed)).NotTo(ContainElement("Namespace cluster-network-addons"), "namespace shared with NMState must be kept")
+				for _, key := range keys(objs) {
+					Expect(keys(removed)).NotTo(ContainElement(key))
+				}
+			})
+		})
+
+		Context("when a component was removed and the inventory of applied objects is recorded", func() {
+			prev := &opv1.NetworkAddonsConfigSpec{ImagePullPolicy: v1.PullAlways, Ovs: &opv1.Ovs{}, NMState: &opv1.NMState{}}
+			conf := &opv1.NetworkAddonsConfigSpec{ImagePullPolicy: v1.PullAlways, NMState: &opv1.NMState{}}
+
+			It("should return objects of the removed component under their recorded names", func() {
+				components, err := RenderComponents(prev, "../../data", nil, clusterInfo)
+				Expect(err).NotTo(HaveOccurred())
+				prevObjs, err := Render(prev, "../../data", nil, clusterInfo)
+				Expect(err).NotTo(HaveOccurred())
+				inventory := Inventory(components, prevObjs)
+				// Objects applied by an older version under a different name
+				inventory = append(inventory, apply.InventoryItem{Group: "apps", Version: "v1", Kind: "DaemonSet", Namespace: "cluster-network-addons", Name: "ovs-cni-amd64", Components: []string{OvsComponent}})
+
+				objs, err := Render(conf, "../../data", nil, clusterInfo)
+				Expect(err).NotTo(HaveOccurred())
+
+				removed, err := RenderRemoved(prev, conf, inventory, objs, "../../data", nil, clusterInfo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys(removed)).To(ContainElement("DaemonSet ovs-cni"))
+				Expect(keys(removed)).To(ContainElement("DaemonSet ovs-cni-amd64"))
+				Expect(keys(removed)).NotTo(ContainElement("DaemonSet nmstate-handler"))
+				Expect(keys(removed)).NotTo(ContainElement("Namespace cluster-network-addons"), "namespace shared with NMState must be kept")
+			})
+		})
+
+		Context("when the last component was removed", func() {
+			prev := &opv1.NetworkAddonsConfigSpec{ImagePullPolicy: v1.PullAlways, Ovs: &opv1.Ovs{}}
+			conf := &opv1.NetworkAddonsConfigSpec{ImagePullPolicy: v1.PullAlways}
+
+			It("should render all its objects, including the namespace", func() {
+				removed, err := RenderRemoved(prev, conf, nil, nil, "../../data", nil, clusterInfo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(keys(removed)).To(ContainElement("DaemonSet ovs-cni"))
+				Expect(keys(removed)).To(ContainElement("Namespace cluster-network-addons"))
+			})
+		})
+	})
+})
@@ -0,0 +1,146 @@
+package network
+
+import (
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+// Containers of respective components, their resources can be overridden in the configuration
+var (
+	multusContainers      = []string{"kube-multus"}
+	linuxBridgeContainers = []string{"cni-plugins", "bridge-marker"}
+	ovsContainers         = []string{"ovs-cni-plugin", "ovs-cni-marker"}
+	kubeMacPoolContainers = []string{"manager"}
+	nmstateContainers     = []string{"nmstate-handler"}
+)
+
+func validateResources(conf *opv1.NetworkAddonsConfigSpec) []error {
+	errs := []error{}
+
+	if conf.Multus != nil {
+		errs = append(errs, validateComponentResources("multus", conf.Multus.Resources, multusContainers)...)
+	}
+	if conf.LinuxBridge != nil {
+		errs = append(errs, validateComponentResources("linuxBridge", conf.LinuxBridge.Resources, linuxBridgeContainers)...)
+	}
+	if conf.Ovs != nil {
+		errs = append(errs, validateComponentResources("ovs", conf.Ovs.Resources, ovsContainers)...)
+	}
+	if conf.KubeMacPool != nil {
+		errs = append(errs, validateComponentResources("kubeMacPool", conf.KubeMacPool.Resources, kubeMacPoolContainers)...)
+	}
+	if conf.NMState != nil {
+		errs = append(errs, validateComponentResources("nmstate", conf.NMState.Resources, nmstateContainers)...)
+	}
+
+	return errs
+}
+
+func validateComponentResources(component string, resources map[string]v1.ResourceRequirements, containers []string) []error {
+	errs := []error{}
+
+	for container, requirements := range resources {
+		path := component + ".resources." + container
+		if !containsString(containers, container) {
+			errs = append(errs, errors.Errorf("%s: unknown container '%s', expected one of %v", path, container, containers))
+			continue
+		}
+
+		for name, quantity := range requirements.Limits {
+			if quantity.Sign() < 0 {
+				errs = append(errs, errors.Errorf("%s.limits.%s must not be negative", path, name))
+			}
+		}
+		for name, quantity := range requirements.Requests {
+			if quantity.Sign() < 0 {
+				errs = append(errs, errors.Errorf("%s.requests.%s must not be negative", path, name))
+			}
+			if limit, found := requirements.Limits[name]; found && quantity.Cmp(limit) > 0 {
+				errs = append(errs, errors.Errorf("%s.requests.%s must be less than or equal to its limit", path, name))
+			}
+		}
+	}
+
+	return errs
+}
+
+// overrideResources replaces requests and limits of containers of rendered DaemonSets and
+// Deployments by those configured. Each configured resource replaces only the respective resource
+// of the template, others are kept
+func overrideResources(objs []*unstructured.Unstructured, resources map[string]v1.ResourceRequirements) error {
+	if len(resources) == 0 {
+		return nil
+	}
+
+	for _, obj := range objs {
+		if obj.GetKind() != "DaemonSet" && obj.GetKind() != "Deployment" {
+			continue
+		}
+
+		containers, found, err := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+		if err != nil {
+			return errors.Wrapf(err, "failed to read containers of %s %s", obj.GetKind(), obj.GetName())
+		}
+		if !found {
+			continue
+		}
+
+		for i, container := range containers {
+			container, ok := container.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(container, "name")
+			override, configured := resources[name]
+			if !configured {
+				continue
+			}
+
+			requirements := v1.ResourceRequirements{}
+			if current, found := container["resources"].(map[string]interface{}); found {
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(current, &requirements); err != nil {
+					return errors.Wrapf(err, "failed to read resources of container %s", name)
+				}
+			}
+
+			requirements.Limits = mergeResourceList(requirements.Limits, override.Limits)
+			requirements.Requests = mergeResourceList(requirements.Requests, override.Requests)
+			for resource, request := range requirements.Requests {
+				if limit, found := requirements.Limits[resource]; found && request.Cmp(limit) > 0 {
+					return errors.Errorf("request of %s of container %s (%s) would exceed its limit (%s)", resource, name, request.String(), limit.String())
+				}
+			}
+
+			merged, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&requirements)
+			if err != nil {
+				return errors.Wrapf(err, "failed to convert resources of container %s", name)
+			}
+			container["resources"] = merged
+			containers[i] = container
+		}
+
+		if err := unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers"); err != nil {
+			return errors.Wrapf(err, "failed to set containers of %s %s", obj.GetKind(), obj.GetName())
+		}
+	}
+
+	return nil
+}
+
+func mergeResourceList(base, override v1.ResourceList) v1.ResourceList {
+	if len(override) == 0 {
+		return base
+	}
+	merged := v1.ResourceList{}
+	for name, quantity := range base {
+		merged[name] = quantity
+	}
+	for name, quantity := range override {
+		merged[name] = quantity
+	}
+	return merged
+}
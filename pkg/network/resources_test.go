@@ -0,0 +1,152 @@
+package network
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+var _ = Describe("Testing resources", func() {
+	clusterInfo := &ClusterInfo{SCCAvailable: true, OpenShift4: false}
+
+	containerResources := func(objs []*unstructured.Unstructured, kind, name, container string) v1.ResourceRequirements {
+		for _, obj := range objs {
+			if obj.GetKind() != kind || obj.GetName() != name {
+				continue
+			}
+			spec, _, err := unstructured.NestedMap(obj.Object, "spec", "template", "spec")
+			Expect(err).NotTo(HaveOccurred())
+			podSpec := v1.PodSpec{}
+			Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(spec, &podSpec)).To(Succeed())
+			for _, c := range podSpec.Containers {
+				if c.Name == container {
+					return c.Resources
+				}
+			}
+		}
+		Fail("container " + container + " of " + kind + " " + name + " was not rendered")
+		return v1.ResourceRequirements{}
+	}
+
+	Describe("validateResources", func() {
+		Context("when resources of known containers are configured", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{
+				KubeMacPool: &opv1.KubeMacPool{
+					Resources: map[string]v1.ResourceRequirements{
+						"manager": {
+							Limits:   v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+							Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("500Mi")},
+						},
+					},
+				},
+			}
+
+			It("should pass", func() {
+				Expect(validateResources(conf)).To(BeEmpty())
+			})
+		})
+
+		Context("when resources are invalid", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{
+				LinuxBridge: &opv1.LinuxBridge{
+					Resources: map[string]v1.ResourceRequirements{
+						"foo": {},
+						"bridge-marker": {
+							Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m")},
+							Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("200m"), v1.ResourceMemory: resource.MustParse("-1")},
+						},
+					},
+				},
+			}
+
+			It("should report all errors", func() {
+				errs := validateResources(conf)
+				Expect(errs).To(HaveLen(3))
+				Expect(errs).To(ContainElement(MatchError("linuxBridge.resources.foo: unknown container 'foo', expected one of [cni-plugins bridge-marker]")))
+				Expect(errs).To(ContainElement(MatchError("linuxBridge.resources.bridge-marker.requests.cpu must be less than or equal to its limit")))
+				Expect(errs).To(ContainElement(MatchError("linuxBridge.resources.bridge-marker.requests.memory must not be negative")))
+			})
+		})
+	})
+
+	Describe("IsChangeSafe", func() {
+		Context("when only resources of a deployed component are changed", func() {
+			prev := &opv1.NetworkAddonsConfigSpec{NMState: &opv1.NMState{}}
+			next := &opv1.NetworkAddonsConfigSpec{
+				NMState: &opv1.NMState{
+					Resources: map[string]v1.ResourceRequirements{
+						"nmstate-handler": {Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("500Mi")}},
+					},
+				},
+			}
+
+			It("should pass the check", func() {
+				Expect(IsChangeSafe(prev, next)).To(Succeed())
+			})
+		})
+	})
+
+	Describe("Render", func() {
+		Context("when resources are configured", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{
+				ImagePullPolicy: v1.PullAlways,
+				KubeMacPool: &opv1.KubeMacPool{
+					RangeStart: "02:00:00:00:00:00",
+					RangeEnd:   "02:FF:FF:FF:FF:FF",
+					Resources: map[string]v1.ResourceRequirements{
+						"manager": {
+							Limits:   v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+							Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("600Mi")},
+						},
+					},
+				},
+				Ovs: &opv1.Ovs{
+					Resources: map[string]v1.ResourceRequirements{
+						"ovs-cni-marker": {Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("20m")}},
+					},
+				},
+			}
+
+			It("should replace configured resources and keep the others", func() {
+				objs, err := Render(conf, "../../data", nil, clusterInfo)
+				Expect(err).NotTo(HaveOccurred())
+
+				manager := containerResources(objs, "Deployment", "kubemacpool-mac-controller-manager", "manager")
+				Expect(manager.Limits.Memory().String()).To(Equal("1Gi"))
+				Expect(manager.Requests.Memory().String()).To(Equal("600Mi"))
+				Expect(manager.Limits.Cpu().String()).To(Equal("300m"))
+				Expect(manager.Requests.Cpu().String()).To(Equal("100m"))
+
+				marker := containerResources(objs, "DaemonSet", "ovs-cni", "ovs-cni-marker")
+				Expect(marker.Requests.Cpu().String()).To(Equal("20m"))
+
+				plugin := containerResources(objs, "DaemonSet", "ovs-cni", "ovs-cni-plugin")
+				Expect(plugin.Requests.Cpu().String()).NotTo(Equal("20m"))
+			})
+		})
+
+		Context("when configured request exceeds limit of the template", func() {
+			conf := &opv1.NetworkAddonsConfigSpec{
+				ImagePullPolicy: v1.PullAlways,
+				KubeMacPool: &opv1.KubeMacPool{
+					RangeStart: "02:00:00:00:00:00",
+					RangeEnd:   "02:FF:FF:FF:FF:FF",
+					Resources: map[string]v1.ResourceRequirements{
+						"manager": {Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")}},
+					},
+				},
+			}
+
+			It("should fail", func() {
+				_, err := Render(conf, "../../data", nil, clusterInfo)
+				Expect(err).To(MatchError(ContainSubstring("request of memory of container manager (1Gi) would exceed its limit (300Mi)")))
+			})
+		})
+	})
+})
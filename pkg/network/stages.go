@@ -0,0 +1,40 @@
+package network
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// stageKinds names a stage and lists kinds of objects which belong to it. A stage without kinds
+// takes all objects of kinds not listed in any other stage
+type stageKinds struct {
+	name  string
+	kinds []string
+}
+
+// sortIntoStages sorts objects into stages of the table by their kind. The result holds objects of
+// each stage in the order of the table. Objects keep their original order within each stage, so
+// the result is deterministic
+func sortIntoStages(table []stageKinds, objs []*unstructured.Unstructured) [][]*unstructured.Unstructured {
+	stages := [][]*unstructured.Unstructured{}
+	defaultStage := -1
+	stageOfKind := map[string]int{}
+	for i, stage := range table {
+		stages = append(stages, []*unstructured.Unstructured{})
+		if stage.kinds == nil {
+			defaultStage = i
+		}
+		for _, kind := range stage.kinds {
+			stageOfKind[kind] = i
+		}
+	}
+
+	for _, obj := range objs {
+		stage, found := stageOfKind[obj.GetKind()]
+		if !found {
+			stage = defaultStage
+		}
+		stages[stage] = append(stages[stage], obj)
+	}
+
+	return stages
+}
@@ -0,0 +1,46 @@
+package network
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("sortIntoStages", func() {
+	table := []stageKinds{
+		{"First", []string{"Namespace"}},
+		{"Rest", nil},
+		{"Last", []string{"DaemonSet", "Deployment"}},
+	}
+
+	object := func(kind, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetKind(kind)
+		obj.SetName(name)
+		return obj
+	}
+
+	It("should sort objects by their kind and keep their order within each stage", func() {
+		deployment := object("Deployment", "d1")
+		configMap := object("ConfigMap", "cm1")
+		daemonSet := object("DaemonSet", "ds1")
+		namespace := object("Namespace", "ns1")
+		service := object("Service", "svc1")
+
+		stages := sortIntoStages(table, []*unstructured.Unstructured{deployment, configMap, daemonSet, namespace, service})
+		Expect(stages).To(Equal([][]*unstructured.Unstructured{
+			{namespace},
+			{configMap, service},
+			{deployment, daemonSet},
+		}))
+	})
+
+	It("should return empty stages when there are no objects of their kinds", func() {
+		stages := sortIntoStages(table, nil)
+		Expect(stages).To(HaveLen(3))
+		for _, stage := range stages {
+			Expect(stage).To(BeEmpty())
+		}
+	})
+})
@@ -0,0 +1,97 @@
+package network
+
+import (
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TeardownStage is a group of objects which are removed together when NetworkAddonsConfig is
+// deleted. The next stage is started only once all objects of the previous one are gone
+type TeardownStage struct {
+	Name    string
+	Objects []*unstructured.Unstructured
+}
+
+// Stages of the teardown and kinds of objects removed in them, all remaining kinds are removed in
+// the Configuration stage:
+//   - Webhooks go first, so API requests are not blocked by webhook servers which are going away.
+//   - Workloads are removed before their RBAC, so pods are not left running without permissions.
+//   - CRDs and namespaces go last, their removal takes the longest and may remove user data.
+var teardownStageKinds = []stageKinds{
+	{"Webhooks", []string{"MutatingWebhookConfiguration", "ValidatingWebhookConfiguration"}},
+	{"Workloads", []string{"DaemonSet", "Deployment", "PodDisruptionBudget"}},
+	{"Configuration", nil},
+	{"CRDs and namespaces", []string{"CustomResourceDefinition", "Namespace"}},
+}
+
+// TeardownStages sorts objects of deployed components into stages in which they have to be removed
+func TeardownStages(objs []*unstructured.Unstructured) []TeardownStage {
+	stages := []TeardownStage{}
+	for i, stageObjs := range sortIntoStages(teardownStageKinds, objs) {
+		stages = append(stages, TeardownStage{Name: teardownStageKinds[i].name, Objects: stageObjs})
+	}
+	return stages
+}
+
+// runtimeWebhook describes a mutating webhook configuration and the Service it calls, both created
+// on runtime by a deployed workload. The Service lives in the namespace of the workload
+type runtimeWebhook struct {
+	workloadKind  string
+	workloadName  string
+	configuration string
+	service       string
+}
+
+// runtimeWebhooks lists all objects components are known to create on runtime
+var runtimeWebhooks = []runtimeWebhook{
+	// KubeMacPool manager registers its webhook once it is elected as the leader
+	{"Deployment", "kubemacpool-mac-controller-manager", "kubemacpool", "kubemacpool-service"},
+}
+
+// RuntimeObjects finds objects which are not rendered by the operator, but are created on runtime
+// by deployed components, e.g. the mutating webhook KubeMacPool registers together with a Service
+// exposing it. They are not owned by NetworkAddonsConfig and have to be removed explicitly. Only
+// objects listed in runtimeWebhooks are recognized, by their exact names, and only if the webhook
+// configuration calls the Service in the namespace of the deployed workload. Objects of other
+// products sharing the namespace are never touched
+func RuntimeObjects(deployed []*unstructured.Unstructured, mutating []admissionregistrationv1beta1.MutatingWebhookConfiguration) []*unstructured.Unstructured {
+	objs := []*unstructured.Unstructured{}
+	for _, runtimeWebhook := range runtimeWebhooks {
+		for _, workload := range deployed {
+			if workload.GetKind() != runtimeWebhook.workloadKind || workload.GetName() != runtimeWebhook.workloadName {
+				continue
+			}
+			service := types.NamespacedName{Namespace: workload.GetNamespace(), Name: runtimeWebhook.service}
+
+			for _, config := range mutating {
+				if config.Name != runtimeWebhook.configuration || !callsService(config.Webhooks, service) {
+					continue
+				}
+				objs = append(objs, objectOf("admissionregistration.k8s.io", "v1beta1", "MutatingWebhookConfiguration", "", config.Name))
+				objs = append(objs, objectOf("", "v1", "Service", service.Namespace, service.Name))
+			}
+		}
+	}
+	return objs
+}
+
+// callsService checks whether any of the webhooks calls the given Service
+func callsService(webhooks []admissionregistrationv1beta1.Webhook, service types.NamespacedName) bool {
+	for _, webhook := range webhooks {
+		ref := webhook.ClientConfig.Service
+		if ref != nil && ref.Namespace == service.Namespace && ref.Name == service.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func objectOf(group, version, kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: group, Version: version, Kind: kind})
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
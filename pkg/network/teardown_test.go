@@ -0,0 +1,134 @@
+package network
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+)
+
+// webhookConfiguration returns a mutating webhook configuration calling the given Service, the
+// same as KubeMacPool registers on runtime
+func webhookConfiguration(name, serviceNamespace, serviceName string) admissionregistrationv1beta1.MutatingWebhookConfiguration {
+	return admissionregistrationv1beta1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Webhooks: []admissionregistrationv1beta1.Webhook{{
+			Name: "mutatepods." + name + ".io",
+			ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
+				Service: &admissionregistrationv1beta1.ServiceReference{Namespace: serviceNamespace, Name: serviceName},
+			},
+		}},
+	}
+}
+
+var _ = Describe("Testing teardown", func() {
+	Describe("TeardownStages", func() {
+		clusterInfo := &ClusterInfo{SCCAvailable: true, OpenShift4: false}
+		conf := &opv1.NetworkAddonsConfigSpec{
+			ImagePullPolicy: v1.PullAlways,
+			KubeMacPool:     &opv1.KubeMacPool{RangeStart: "02:00:00:00:00:00", RangeEnd: "02:FF:FF:FF:FF:FF"},
+			NMState:         &opv1.NMState{},
+		}
+
+		BeforeEach(func() {
+			os.Setenv("OPERAND_NAMESPACE", "cluster-network-addons")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("OPERAND_NAMESPACE")
+		})
+
+		It("should remove webhooks, workloads, their configuration and finally CRDs and namespaces", func() {
+			objs, err := Render(conf, "../../data", nil, clusterInfo)
+			Expect(err).NotTo(HaveOccurred())
+			mutating := []admissionregistrationv1beta1.MutatingWebhookConfiguration{webhookConfiguration("kubemacpool", "cluster-network-addons", "kubemacpool-service")}
+			objs = append(objs, RuntimeObjects(objs, mutating)...)
+
+			stages := TeardownStages(objs)
+
+			kindsOf := func(stage TeardownStage) map[string]bool {
+				kinds := map[string]bool{}
+				for _, obj := range stage.Objects {
+					kinds[obj.GetKind()] = true
+				}
+				return kinds
+			}
+
+			Expect(stages).To(HaveLen(4))
+			Expect(stages[0].Name).To(Equal("Webhooks"))
+			Expect(kindsOf(stages[0])).To(Equal(map[string]bool{"MutatingWebhookConfiguration": true}))
+			Expect(stages[1].Name).To(Equal("Workloads"))
+			Expect(kindsOf(stages[1])).To(Equal(map[string]bool{"DaemonSet": true, "Deployment": true, "PodDisruptionBudget": true}))
+			Expect(stages[2].Name).To(Equal("Configuration"))
+			Expect(kindsOf(stages[2])).To(HaveKey("ClusterRole"))
+			Expect(kindsOf(stages[2])).To(HaveKey("Service"))
+			Expect(kindsOf(stages[2])).NotTo(HaveKey("CustomResourceDefinition"))
+			Expect(stages[3].Name).To(Equal("CRDs and namespaces"))
+			Expect(kindsOf(stages[3])).To(Equal(map[string]bool{"CustomResourceDefinition": true, "Namespace": true}))
+
+			total := 0
+			for _, stage := range stages {
+				total += len(stage.Objects)
+			}
+			Expect(total).To(Equal(len(objs)))
+		})
+	})
+
+	Describe("RuntimeObjects", func() {
+		keys := func(objs []*unstructured.Unstructured) []string {
+			keys := []string{}
+			for _, obj := range objs {
+				keys = append(keys, obj.GetKind()+" "+obj.GetNamespace()+"/"+obj.GetName())
+			}
+			return keys
+		}
+
+		deployed := []*unstructured.Unstructured{
+			objectOf("apps", "v1", "Deployment", "cluster-network-addons", "kubemacpool-mac-controller-manager"),
+			objectOf("", "v1", "ConfigMap", "kube-system", "unrelated"),
+		}
+
+		It("should find the webhook of KubeMacPool together with its service", func() {
+			mutating := []admissionregistrationv1beta1.MutatingWebhookConfiguration{
+				webhookConfiguration("kubemacpool", "cluster-network-addons", "kubemacpool-service"),
+				webhookConfiguration("unrelated", "kube-system", "unrelated-service"),
+			}
+			Expect(keys(RuntimeObjects(deployed, mutating))).To(Equal([]string{
+				"MutatingWebhookConfiguration /kubemacpool",
+				"Service cluster-network-addons/kubemacpool-service",
+			}))
+		})
+
+		Context("when another product registers a webhook in the same namespace", func() {
+			It("should leave it and its service alone", func() {
+				mutating := []admissionregistrationv1beta1.MutatingWebhookConfiguration{
+					webhookConfiguration("foreign", "cluster-network-addons", "foreign-service"),
+				}
+				Expect(RuntimeObjects(deployed, mutating)).To(BeEmpty())
+			})
+		})
+
+		Context("when a webhook named as the one of KubeMacPool calls a foreign service", func() {
+			It("should leave it alone", func() {
+				mutating := []admissionregistrationv1beta1.MutatingWebhookConfiguration{
+					webhookConfiguration("kubemacpool", "cluster-network-addons", "foreign-service"),
+				}
+				Expect(RuntimeObjects(deployed, mutating)).To(BeEmpty())
+			})
+		})
+
+		Context("when no workload is deployed", func() {
+			It("should find nothing", func() {
+				mutating := []admissionregistrationv1beta1.MutatingWebhookConfiguration{webhookConfiguration("kubemacpool", "cluster-network-addons", "kubemacpool-service")}
+				Expect(RuntimeObjects(nil, mutating)).To(BeEmpty())
+			})
+		})
+	})
+})
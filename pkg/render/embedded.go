@@ -0,0 +1,81 @@
+package render
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// EmbeddedManifests returns slash separated paths of all manifest templates
+// compiled into the binary, relative to the data directory they were taken from
+func EmbeddedManifests() []string {
+	paths := []string{}
+	for path := range embeddedManifests {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return walkOrderLess(paths[i], paths[j])
+	})
+	return paths
+}
+
+// ReadEmbeddedManifest returns content of a manifest template compiled into the binary
+func ReadEmbeddedManifest(path string) ([]byte, error) {
+	source, found := embeddedManifests[path]
+	if !found {
+		return nil, errors.Errorf("embedded manifest %s not found", path)
+	}
+	return []byte(source), nil
+}
+
+// RenderEmbeddedDir behaves like RenderDir, but reads the manifest templates
+// from the data directory compiled into the binary instead of the disk
+func RenderEmbeddedDir(manifestDir string, d *RenderData) ([]*unstructured.Unstructured, error) {
+	out := []*unstructured.Unstructured{}
+
+	prefix := strings.TrimSuffix(manifestDir, "/") + "/"
+	found := false
+	for _, path := range EmbeddedManifests() {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		found = true
+
+		// Skip non-manifest files
+		if !isManifest(path) {
+			continue
+		}
+
+		source, err := ReadEmbeddedManifest(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "error rendering manifests")
+		}
+
+		objs, err := renderTemplate(path, source, d)
+		if err != nil {
+			return nil, errors.Wrap(err, "error rendering manifests")
+		}
+		out = append(out, objs...)
+	}
+
+	if !found {
+		return nil, errors.Errorf("error rendering manifests: embedded directory %s not found", manifestDir)
+	}
+
+	return out, nil
+}
+
+// walkOrderLess orders paths the same way filepath.Walk visits them, so
+// embedded manifests are rendered in the same order as those on the disk
+func walkOrderLess(a, b string) bool {
+	aElements := strings.Split(a, "/")
+	bElements := strings.Split(b, "/")
+	for i := 0; i < len(aElements) && i < len(bElements); i++ {
+		if aElements[i] != bElements[i] {
+			return aElements[i] < bElements[i]
+		}
+	}
+	return len(aElements) < len(bElements)
+}
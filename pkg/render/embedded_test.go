@@ -0,0 +1,73 @@
+package render_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/render"
+)
+
+const dataDir = "../../data"
+
+var _ = Describe("EmbeddedManifests", func() {
+	It("should match content of the data directory, run make gen-data if it does not", func() {
+		onDisk := []string{}
+		err := filepath.Walk(dataDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			relPath, err := filepath.Rel(dataDir, path)
+			if err != nil {
+				return err
+			}
+			onDisk = append(onDisk, filepath.ToSlash(relPath))
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(render.EmbeddedManifests()).To(Equal(onDisk))
+
+		for _, path := range onDisk {
+			expected, err := ioutil.ReadFile(filepath.Join(dataDir, filepath.FromSlash(path)))
+			Expect(err).NotTo(HaveOccurred())
+
+			embedded, err := render.ReadEmbeddedManifest(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(embedded).To(Equal(expected), "embedded manifest %s differs from the data directory", path)
+		}
+	})
+})
+
+var _ = Describe("RenderEmbeddedDir", func() {
+	Context("when rendering an embedded directory", func() {
+		It("should render the same objects as RenderDir on the data directory", func() {
+			renderData := render.MakeRenderData()
+			renderData.Data["Namespace"] = "myns"
+			renderData.Data["OvsCNIImage"] = "ovs-cni"
+			renderData.Data["OvsMarkerImage"] = "ovs-marker"
+			renderData.Data["ImagePullPolicy"] = "Always"
+			renderData.Data["EnableSCC"] = false
+			renderData.Data["CNIBinDir"] = "/opt/cni/bin"
+			renderData.Data["Placement"] = map[string]interface{}{"Affinity": nil, "NodeSelector": nil, "Tolerations": nil}
+
+			fromDisk, err := render.RenderDir(filepath.Join(dataDir, "ovs"), &renderData)
+			Expect(err).NotTo(HaveOccurred())
+
+			embedded, err := render.RenderEmbeddedDir("ovs", &renderData)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(embedded).To(Equal(fromDisk))
+		})
+	})
+
+	Context("when the directory is not embedded", func() {
+		It("should fail", func() {
+			renderData := render.MakeRenderData()
+			_, err := render.RenderEmbeddedDir("nonexistent", &renderData)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
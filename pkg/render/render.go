@@ -41,7 +41,7 @@ func RenderDir(manifestDir string, d *RenderData) ([]*unstructured.Unstructured,
 		}
 
 		// Skip non-manifest files
-		if !(strings.HasSuffix(path, ".yml") || strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".json")) {
+		if !isManifest(path) {
 			return nil
 		}
 
@@ -61,6 +61,15 @@ func RenderDir(manifestDir string, d *RenderData) ([]*unstructured.Unstructured,
 // RenderTemplate reads, renders, and attempts to parse a yaml or
 // json file representing one or more k8s api objects
 func RenderTemplate(path string, d *RenderData) ([]*unstructured.Unstructured, error) {
+	source, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read manifest %s", path)
+	}
+
+	return renderTemplate(path, source, d)
+}
+
+func renderTemplate(path string, source []byte, d *RenderData) ([]*unstructured.Unstructured, error) {
 	tmpl := template.New(path).Option("missingkey=error")
 	if d.Funcs != nil {
 		tmpl.Funcs(d.Funcs)
@@ -69,11 +78,6 @@ func RenderTemplate(path string, d *RenderData) ([]*unstructured.Unstructured, e
 	// Add universal functions
 	tmpl.Funcs(sprig.TxtFuncMap())
 
-	source, err := ioutil.ReadFile(path)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to read manifest %s", path)
-	}
-
 	if _, err := tmpl.Parse(string(source)); err != nil {
 		return nil, errors.Wrapf(err, "failed to parse manifest %s as template", path)
 	}
@@ -105,3 +109,7 @@ func RenderTemplate(path string, d *RenderData) ([]*unstructured.Unstructured, e
 
 	return out, nil
 }
+
+func isManifest(path string) bool {
+	return strings.HasSuffix(path, ".yml") || strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".json")
+}
@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+)
+
+// ConversionWebhook converts custom resources between API versions using conversion functions
+// registered in the scheme
+type ConversionWebhook struct {
+	// CRDName is the name of the CustomResourceDefinition which is served by this webhook
+	CRDName string
+	// Path is the path this webhook will serve
+	Path string
+
+	scheme *runtime.Scheme
+	codecs serializer.CodecFactory
+}
+
+var _ http.Handler = &ConversionWebhook{}
+
+// NewConversionWebhook returns a webhook converting objects of the given CRD between all versions
+// known by the scheme
+func NewConversionWebhook(crdName string, scheme *runtime.Scheme) *ConversionWebhook {
+	return &ConversionWebhook{
+		CRDName: crdName,
+		Path:    "/convert-" + crdName,
+		scheme:  scheme,
+		codecs:  serializer.NewCodecFactory(scheme),
+	}
+}
+
+func (wh *ConversionWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := &extv1beta1.ConversionReview{}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+		http.Error(w, fmt.Sprintf("failed to decode ConversionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	review.Response = wh.convert(review.Request)
+	review.Request = nil
+
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		log.Printf("failed to encode ConversionReview: %v", err)
+	}
+}
+
+// convert converts all objects of the request to the desired API version
+func (wh *ConversionWebhook) convert(req *extv1beta1.ConversionRequest) *extv1beta1.ConversionResponse {
+	resp := &extv1beta1.ConversionResponse{
+		UID: req.UID,
+	}
+
+	desiredGroupVersion, err := schema.ParseGroupVersion(req.DesiredAPIVersion)
+	if err != nil {
+		resp.Result = conversionFailure(err)
+		return resp
+	}
+
+	for _, raw := range req.Objects {
+		obj, _, err := wh.codecs.UniversalDeserializer().Decode(raw.Raw, nil, nil)
+		if err != nil {
+			resp.Result = conversionFailure(err)
+			return resp
+		}
+
+		converted, err := wh.scheme.ConvertToVersion(obj, desiredGroupVersion)
+		if err != nil {
+			resp.Result = conversionFailure(err)
+			return resp
+		}
+
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Object: converted})
+	}
+
+	resp.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return resp
+}
+
+func conversionFailure(err error) metav1.Status {
+	log.Printf("failed to convert objects: %v", err)
+	return metav1.Status{
+		Status:  metav1.StatusFailure,
+		Message: err.Error(),
+	}
+}
@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apis"
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	opv1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+)
+
+var _ = Describe("Testing conversion webhook", func() {
+	var webhook *ConversionWebhook
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(apis.AddToScheme(scheme)).To(Succeed())
+		webhook = NewConversionWebhook("networkaddonsconfigs.networkaddonsoperator.network.kubevirt.io", scheme)
+	})
+
+	review := func(desiredAPIVersion string, objs ...interface{}) *extv1beta1.ConversionReview {
+		request := &extv1beta1.ConversionReview{
+			Request: &extv1beta1.ConversionRequest{
+				UID:               "uid",
+				DesiredAPIVersion: desiredAPIVersion,
+			},
+		}
+		for _, obj := range objs {
+			raw, err := json.Marshal(obj)
+			Expect(err).NotTo(HaveOccurred())
+			request.Request.Objects = append(request.Request.Objects, runtime.RawExtension{Raw: raw})
+		}
+
+		body, err := json.Marshal(request)
+		Expect(err).NotTo(HaveOccurred())
+
+		recorder := httptest.NewRecorder()
+		webhook.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, webhook.Path, bytes.NewReader(body)))
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		response := &extv1beta1.ConversionReview{}
+		Expect(json.Unmarshal(recorder.Body.Bytes(), response)).To(Succeed())
+		Expect(response.Response).NotTo(BeNil())
+		Expect(response.Response.UID).To(BeEquivalentTo("uid"))
+		return response
+	}
+
+	Context("when v1alpha1 config is requested in v1", func() {
+		It("should convert it", func() {
+			config := &opv1alpha1.NetworkAddonsConfig{
+				TypeMeta:   metav1.TypeMeta{APIVersion: opv1alpha1.SchemeGroupVersion.String(), Kind: "NetworkAddonsConfig"},
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec: opv1alpha1.NetworkAddonsConfigSpec{
+					KubeMacPool: &opv1alpha1.KubeMacPool{RangeStart: "02:00:00:00:00:00", RangeEnd: "02:FF:FF:FF:FF:FF"},
+					LinuxBridge: &opv1alpha1.LinuxBridge{},
+				},
+			}
+
+			response := review(opv1.SchemeGroupVersion.String(), config)
+			Expect(response.Response.Result.Status).To(Equal(metav1.StatusSuccess))
+			Expect(response.Response.ConvertedObjects).To(HaveLen(1))
+
+			converted := &opv1.NetworkAddonsConfig{}
+			Expect(json.Unmarshal(response.Response.ConvertedObjects[0].Raw, converted)).To(Succeed())
+			Expect(converted.APIVersion).To(Equal(opv1.SchemeGroupVersion.String()))
+			Expect(converted.Kind).To(Equal("NetworkAddonsConfig"))
+			Expect(converted.Name).To(Equal("cluster"))
+			Expect(converted.Spec).To(Equal(opv1.NetworkAddonsConfigSpec{
+				KubeMacPool: &opv1.KubeMacPool{RangeStart: "02:00:00:00:00:00", RangeEnd: "02:FF:FF:FF:FF:FF"},
+				LinuxBridge: &opv1.LinuxBridge{},
+			}))
+		})
+	})
+
+	Context("when the desired version is unknown", func() {
+		It("should report failure", func() {
+			config := &opv1.NetworkAddonsConfig{
+				TypeMeta:   metav1.TypeMeta{APIVersion: opv1.SchemeGroupVersion.String(), Kind: "NetworkAddonsConfig"},
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			}
+
+			response := review(opv1.SchemeGroupVersion.Group+"/v2", config)
+			Expect(response.Response.Result.Status).To(Equal(metav1.StatusFailure))
+		})
+	})
+})
@@ -0,0 +1,379 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	certutil "k8s.io/client-go/util/cert"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/types"
+
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/apply"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/components"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/names"
+	k8sutil "github.com/kubevirt/cluster-network-addons-operator/pkg/util/k8s"
+)
+
+var _ manager.Runnable = &Server{}
+
+// Server serves admission webhooks of the operator. It is started by the manager once its caches
+// are synced. On start, it signs a serving certificate by its CA, exposes itself through a
+// Service and registers all added webhooks in webhook configurations carrying the CA bundle.
+type Server struct {
+	client    k8sclient.Client
+	crds      crdPatcher
+	namespace string
+	port      int32
+	mux       *http.ServeMux
+	webhooks  []*admission.Webhook
+	converts  []*ConversionWebhook
+}
+
+// crdPatcher patches CustomResourceDefinitions. The client of the manager cannot patch objects
+// and an update would overwrite the whole CRD, including fields it does not know yet
+type crdPatcher interface {
+	Patch(name string, pt k8stypes.PatchType, data []byte, subresources ...string) (*extv1beta1.CustomResourceDefinition, error)
+}
+
+// NewServer returns a new webhook Server exposed in the given namespace. Conversion webhooks are
+// registered in their CRDs using the given CRD client
+func NewServer(client k8sclient.Client, crds crdPatcher, namespace string) *Server {
+	return &Server{
+		client:    client,
+		crds:      crds,
+		namespace: namespace,
+		port:      names.WEBHOOK_PORT,
+		mux:       http.NewServeMux(),
+	}
+}
+
+// Register adds the webhook to the server. It will be served on its path and registered in
+// the respective webhook configuration once the server is started.
+func (s *Server) Register(webhook *admission.Webhook) error {
+	if err := webhook.Validate(); err != nil {
+		return errors.Wrapf(err, "invalid webhook %q", webhook.GetName())
+	}
+	s.mux.Handle(webhook.GetPath(), webhook.Handler())
+	s.webhooks = append(s.webhooks, webhook)
+	return nil
+}
+
+// RegisterConversion adds the conversion webhook to the server. It will be served on its path and
+// set as the conversion strategy of its CRD once the server is started.
+func (s *Server) RegisterConversion(webhook *ConversionWebhook) {
+	s.mux.Handle(webhook.Path, webhook)
+	s.converts = append(s.converts, webhook)
+}
+
+// Start serves registered webhooks until the stop channel is closed
+func (s *Server) Start(stop <-chan struct{}) error {
+	caCert, caKey, err := s.loadOrCreateCA(context.TODO())
+	if err != nil {
+		return errors.Wrap(err, "failed to get webhook CA")
+	}
+
+	serviceName := fmt.Sprintf("%s.%s.svc", names.WEBHOOK_SERVICE, s.namespace)
+	servingCert, err := generateServingCertificate(serviceName, caCert, caKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate webhook serving certificate")
+	}
+
+	server := &http.Server{
+		Addr:      fmt.Sprintf(":%d", s.port),
+		Handler:   s.mux,
+		TLSConfig: &tls.Config{Certificates: []tls.Certificate{*servingCert}},
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("serving webhooks on port %d", s.port)
+		if err := server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+		}
+	}()
+
+	caBundle := certutil.EncodeCertPEM(caCert)
+	if err := s.registerWebhooks(context.TODO(), caBundle); err != nil {
+		server.Close()
+		return errors.Wrap(err, "failed to register webhooks")
+	}
+	if err := s.registerConversions(context.TODO(), caBundle); err != nil {
+		server.Close()
+		return errors.Wrap(err, "failed to register conversion webhooks")
+	}
+
+	select {
+	case <-stop:
+		return server.Shutdown(context.Background())
+	case err := <-errCh:
+		return errors.Wrap(err, "webhook server failed")
+	}
+}
+
+// registerWebhooks exposes the server through a Service and makes sure that webhook
+// configurations point to it and trust its certificate
+func (s *Server) registerWebhooks(ctx context.Context, caBundle []byte) error {
+	objs := []interface{}{s.service()}
+
+	validating := s.webhookConfiguration(types.WebhookTypeValidating, caBundle)
+	if len(validating) > 0 {
+		objs = append(objs, &admissionregistrationv1beta1.ValidatingWebhookConfiguration{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "admissionregistration.k8s.io/v1beta1",
+				Kind:       "ValidatingWebhookConfiguration",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: names.VALIDATING_WEBHOOK_CONFIGURATION,
+			},
+			Webhooks: validating,
+		})
+	}
+
+	mutating := s.webhookConfiguration(types.WebhookTypeMutating, caBundle)
+	if len(mutating) > 0 {
+		objs = append(objs, &admissionregistrationv1beta1.MutatingWebhookConfiguration{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "admissionregistration.k8s.io/v1beta1",
+				Kind:       "MutatingWebhookConfiguration",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: names.MUTATING_WEBHOOK_CONFIGURATION,
+			},
+			Webhooks: mutating,
+		})
+	}
+
+	for _, obj := range objs {
+		u, err := k8sutil.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		if _, err := apply.ApplyObject(ctx, s.client, nil, u); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// registerConversions points CRDs with registered conversion webhooks to the server
+func (s *Server) registerConversions(ctx context.Context, caBundle []byte) error {
+	for _, webhook := range s.converts {
+		if err := s.registerConversion(ctx, webhook, caBundle); err != nil {
+			log.Printf("failed to register conversion webhook for CRD %q: %v", webhook.CRDName, err)
+			return errors.Wrapf(err, "failed to register conversion webhook for CRD %q", webhook.CRDName)
+		}
+	}
+	return nil
+}
+
+// registerConversion sets the webhook as the conversion strategy of its CRD. The API server accepts
+// the Webhook strategy only if unknown fields are not preserved. Only these two fields are patched,
+// the rest of the CRD is left as it was deployed. The vendored CRD type does not have the
+// preserveUnknownFields field yet, the patch is therefore built by hand
+func (s *Server) registerConversion(ctx context.Context, webhook *ConversionWebhook, caBundle []byte) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"preserveUnknownFields": false,
+			"conversion": extv1beta1.CustomResourceConversion{
+				Strategy: extv1beta1.WebhookConverter,
+				WebhookClientConfig: &extv1beta1.WebhookClientConfig{
+					Service: &extv1beta1.ServiceReference{
+						Namespace: s.namespace,
+						Name:      names.WEBHOOK_SERVICE,
+						Path:      &webhook.Path,
+					},
+					CABundle: caBundle,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = s.crds.Patch(webhook.CRDName, k8stypes.MergePatchType, patch)
+	return err
+}
+
+// loadOrCreateCA returns the CA signing serving certificates of the server. It is kept in a
+// Secret, so webhook configurations and CRDs registered by a previous run of the operator keep
+// trusting the server while it starts. A new CA is created if there is none yet or if it expired.
+// The Secret is read as unstructured, so Secrets are not cached by the manager
+func (s *Server) loadOrCreateCA(ctx context.Context) (*x509.Certificate, *rsa.PrivateKey, error) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+	err := s.client.Get(ctx, k8stypes.NamespacedName{Namespace: s.namespace, Name: names.WEBHOOK_CA_SECRET}, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, nil, errors.Wrap(err, "failed to get webhook CA secret")
+	}
+	found := err == nil
+
+	if found {
+		secret := &corev1.Secret{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(existing.Object, secret); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to read webhook CA secret")
+		}
+		caCert, caKey, err := parseCA(secret)
+		if err == nil && time.Now().Before(caCert.NotAfter) {
+			return caCert, caKey, nil
+		}
+		log.Printf("webhook CA secret does not hold a valid CA, creating a new one: %v", err)
+	}
+
+	caCert, caKey, err := generateCA()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate webhook CA")
+	}
+
+	secret, err := k8sutil.ToUnstructured(&corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.WEBHOOK_CA_SECRET,
+			Namespace: s.namespace,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certutil.EncodeCertPEM(caCert),
+			corev1.TLSPrivateKeyKey: certutil.EncodePrivateKeyPEM(caKey),
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if found {
+		secret.SetResourceVersion(existing.GetResourceVersion())
+		err = s.client.Update(ctx, secret)
+	} else {
+		err = s.client.Create(ctx, secret)
+	}
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to store webhook CA secret")
+	}
+
+	return caCert, caKey, nil
+}
+
+// parseCA reads the certificate and the private key of the CA stored in the Secret
+func parseCA(secret *corev1.Secret) (*x509.Certificate, *rsa.PrivateKey, error) {
+	certs, err := certutil.ParseCertsPEM(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := certutil.ParsePrivateKeyPEM(secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New("private key of the CA is not an RSA key")
+	}
+	return certs[0], rsaKey, nil
+}
+
+func (s *Server) service() *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.WEBHOOK_SERVICE,
+			Namespace: s.namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				"name": components.Name,
+			},
+			Ports: []corev1.ServicePort{
+				{
+					Port:       443,
+					TargetPort: intstr.FromInt(int(s.port)),
+				},
+			},
+		},
+	}
+}
+
+func (s *Server) webhookConfiguration(webhookType types.WebhookType, caBundle []byte) []admissionregistrationv1beta1.Webhook {
+	webhooks := []admissionregistrationv1beta1.Webhook{}
+	for _, webhook := range s.webhooks {
+		if webhook.GetType() != webhookType {
+			continue
+		}
+		path := webhook.GetPath()
+		webhooks = append(webhooks, admissionregistrationv1beta1.Webhook{
+			Name:  webhook.GetName(),
+			Rules: webhook.Rules,
+			ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{
+				Service: &admissionregistrationv1beta1.ServiceReference{
+					Namespace: s.namespace,
+					Name:      names.WEBHOOK_SERVICE,
+					Path:      &path,
+				},
+				CABundle: caBundle,
+			},
+			FailurePolicy: webhook.FailurePolicy,
+		})
+	}
+	return webhooks
+}
+
+// generateCA creates a self-signed CA of the webhook server
+func generateCA() (*x509.Certificate, *rsa.PrivateKey, error) {
+	caKey, err := certutil.NewPrivateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	caCert, err := certutil.NewSelfSignedCACert(certutil.Config{CommonName: names.WEBHOOK_SERVICE + "-ca"}, caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return caCert, caKey, nil
+}
+
+// generateServingCertificate creates a serving certificate for the given host signed by the CA.
+// A new one is created on each start of the operator, the CA is kept, see loadOrCreateCA.
+func generateServingCertificate(host string, caCert *x509.Certificate, caKey *rsa.PrivateKey) (*tls.Certificate, error) {
+	key, err := certutil.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	cert, err := certutil.NewSignedCert(certutil.Config{
+		CommonName: host,
+		AltNames:   certutil.AltNames{DNSNames: []string{host}},
+		Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, key, caCert, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	servingCert, err := tls.X509KeyPair(certutil.EncodeCertPEM(cert), certutil.EncodePrivateKeyPEM(key))
+	if err != nil {
+		return nil, err
+	}
+
+	return &servingCert, nil
+}
@@ -0,0 +1,206 @@
+package webhook
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	extv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	atypes "sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/types"
+
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/names"
+)
+
+var _ = Describe("Testing webhook server", func() {
+	Describe("generateServingCertificate", func() {
+		host := "webhook.namespace.svc"
+
+		It("should return serving certificate for the host signed by the CA", func() {
+			caCert, caKey, err := generateCA()
+			Expect(err).NotTo(HaveOccurred())
+			servingCert, err := generateServingCertificate(host, caCert, caKey)
+			Expect(err).NotTo(HaveOccurred())
+
+			cert, err := x509.ParseCertificate(servingCert.Certificate[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cert.DNSNames).To(ConsistOf(host))
+
+			roots := x509.NewCertPool()
+			roots.AddCert(caCert)
+			_, err = cert.Verify(x509.VerifyOptions{DNSName: host, Roots: roots})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("webhookConfiguration", func() {
+		rules := []admissionregistrationv1beta1.RuleWithOperations{
+			{
+				Operations: []admissionregistrationv1beta1.OperationType{admissionregistrationv1beta1.Create},
+				Rule: admissionregistrationv1beta1.Rule{
+					APIGroups:   []string{"example.com"},
+					APIVersions: []string{"v1"},
+					Resources:   []string{"foos"},
+				},
+			},
+		}
+
+		var server *Server
+		BeforeEach(func() {
+			server = NewServer(fake.NewFakeClient(), nil, "namespace")
+			err := server.Register(&admission.Webhook{
+				Name:  "validate.foos.example.com",
+				Type:  types.WebhookTypeValidating,
+				Rules: rules,
+				Handlers: []admission.Handler{
+					admission.HandlerFunc(func(context.Context, atypes.Request) atypes.Response {
+						return admission.ValidationResponse(true, "")
+					}),
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when asked for validating webhooks", func() {
+			It("should return the registered validating webhook pointing to the service", func() {
+				webhooks := server.webhookConfiguration(types.WebhookTypeValidating, []byte("ca"))
+				Expect(webhooks).To(HaveLen(1))
+				Expect(webhooks[0].Name).To(Equal("validate.foos.example.com"))
+				Expect(webhooks[0].Rules).To(Equal(rules))
+				Expect(webhooks[0].ClientConfig.CABundle).To(Equal([]byte("ca")))
+				Expect(webhooks[0].ClientConfig.Service.Name).To(Equal(names.WEBHOOK_SERVICE))
+				Expect(webhooks[0].ClientConfig.Service.Namespace).To(Equal("namespace"))
+				Expect(*webhooks[0].ClientConfig.Service.Path).To(Equal("/validate-foos"))
+			})
+		})
+
+		Context("when asked for mutating webhooks", func() {
+			It("should return none", func() {
+				webhooks := server.webhookConfiguration(types.WebhookTypeMutating, []byte("ca"))
+				Expect(webhooks).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("loadOrCreateCA", func() {
+		var client k8sclient.Client
+		var server *Server
+		BeforeEach(func() {
+			client = fake.NewFakeClient()
+			server = NewServer(client, nil, "namespace")
+		})
+
+		getSecret := func() *corev1.Secret {
+			secret := &corev1.Secret{}
+			Expect(client.Get(context.TODO(), k8stypes.NamespacedName{Namespace: "namespace", Name: names.WEBHOOK_CA_SECRET}, secret)).To(Succeed())
+			return secret
+		}
+
+		It("should store a new CA in a secret", func() {
+			caCert, _, err := server.loadOrCreateCA(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			stored, _, err := parseCA(getSecret())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Equal(caCert)).To(BeTrue())
+		})
+
+		It("should reuse the stored CA on the next start", func() {
+			first, _, err := server.loadOrCreateCA(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			second, _, err := NewServer(client, nil, "namespace").loadOrCreateCA(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second.Equal(first)).To(BeTrue())
+		})
+
+		It("should replace a stored CA which cannot be read", func() {
+			Expect(client.Create(context.TODO(), &corev1.Secret{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: names.WEBHOOK_CA_SECRET},
+				Data:       map[string][]byte{corev1.TLSCertKey: []byte("garbage")},
+			})).To(Succeed())
+
+			caCert, _, err := server.loadOrCreateCA(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			stored, _, err := parseCA(getSecret())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.Equal(caCert)).To(BeTrue())
+		})
+	})
+
+	Describe("registerConversion", func() {
+		const crdName = "foos.example.com"
+
+		var crds *fakeCRDPatcher
+		var server *Server
+		BeforeEach(func() {
+			crds = &fakeCRDPatcher{crds: map[string]bool{crdName: true}}
+			server = NewServer(fake.NewFakeClient(), crds, "namespace")
+		})
+
+		It("should patch only the conversion strategy and preserving of unknown fields", func() {
+			webhook := &ConversionWebhook{CRDName: crdName, Path: "/convert-foos"}
+			Expect(server.registerConversion(context.TODO(), webhook, []byte("ca"))).To(Succeed())
+
+			Expect(crds.patched).To(Equal(crdName))
+			Expect(crds.patchType).To(Equal(k8stypes.MergePatchType))
+
+			patch := map[string]interface{}{}
+			Expect(json.Unmarshal(crds.patch, &patch)).To(Succeed())
+			Expect(patch).To(HaveLen(1))
+			spec, _, err := unstructured.NestedMap(patch, "spec")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec).To(HaveLen(2))
+
+			preserveUnknownFields, found, err := unstructured.NestedBool(patch, "spec", "preserveUnknownFields")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(preserveUnknownFields).To(BeFalse())
+
+			strategy, _, err := unstructured.NestedString(patch, "spec", "conversion", "strategy")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strategy).To(Equal("Webhook"))
+
+			path, _, err := unstructured.NestedString(patch, "spec", "conversion", "webhookClientConfig", "service", "path")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal("/convert-foos"))
+		})
+
+		It("should fail when the CRD does not exist", func() {
+			webhook := &ConversionWebhook{CRDName: "bars.example.com", Path: "/convert-bars"}
+			Expect(server.registerConversion(context.TODO(), webhook, []byte("ca"))).NotTo(Succeed())
+		})
+	})
+})
+
+// fakeCRDPatcher records the last patch of an existing CRD
+type fakeCRDPatcher struct {
+	crds      map[string]bool
+	patched   string
+	patchType k8stypes.PatchType
+	patch     []byte
+}
+
+func (p *fakeCRDPatcher) Patch(name string, pt k8stypes.PatchType, data []byte, subresources ...string) (*extv1beta1.CustomResourceDefinition, error) {
+	if !p.crds[name] {
+		return nil, apierrors.NewNotFound(extv1beta1.Resource("customresourcedefinitions"), name)
+	}
+	p.patched = name
+	p.patchType = pt
+	p.patch = data
+	return &extv1beta1.CustomResourceDefinition{}, nil
+}
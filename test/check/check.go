@@ -19,6 +19,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -55,9 +57,8 @@ func CheckComponentsRemoval(components []Component) {
 			continue
 		}
 
-		// TODO: Once finalizers are implemented, we should switch to using
-		// once-time checks, since after NodeNetworkState removal, no components
-		// should be left over.
+		// Components of a deleted config are gone once the config is removed, but removal of
+		// a single component from the config is done in background, so keep checking
 		By(fmt.Sprintf("Checking that component %s has been removed", component.ComponentName))
 		Eventually(func() error {
 			return checkForComponentRemoval(&component)
@@ -65,6 +66,16 @@ func CheckComponentsRemoval(components []Component) {
 	}
 }
 
+// CheckComponentsRestoration waits for removed objects of components to be restored by the operator
+func CheckComponentsRestoration(components []Component) {
+	for _, component := range components {
+		By(fmt.Sprintf("Checking that component %s has been restored", component.ComponentName))
+		Eventually(func() error {
+			return checkForComponent(&component)
+		}, 5*time.Minute, time.Second).ShouldNot(HaveOccurred(), "Component has not been restored within the given timeout")
+	}
+}
+
 func CheckConfigCondition(conditionType ConditionType, conditionStatus ConditionStatus, timeout time.Duration, duration time.Duration) {
 	By(fmt.Sprintf("Checking that condition %q status is set to %s", conditionType, conditionStatus))
 	config := &opv1alpha1.NetworkAddonsConfig{}
@@ -273,6 +284,18 @@ func checkForComponentRemoval(component *Component) error {
 		errsAppend(checkForSecurityContextConstraintsRemoval(component.SecurityContextConstraints))
 	}
 
+	for _, daemonSet := range component.DaemonSets {
+		errsAppend(checkForDaemonSetRemoval(daemonSet))
+	}
+
+	for _, deployment := range component.Deployments {
+		errsAppend(checkForDeploymentRemoval(deployment))
+	}
+
+	for _, crd := range component.CustomResourceDefinitions {
+		errsAppend(checkForCustomResourceDefinitionRemoval(crd))
+	}
+
 	return errsToErr(errs)
 }
 
@@ -373,6 +396,23 @@ func checkForSecurityContextConstraintsRemoval(name string) error {
 	return isNotFound("SecurityContextConstraints", name, err)
 }
 
+func checkForDaemonSetRemoval(name string) error {
+	err := framework.Global.Client.Get(context.Background(), types.NamespacedName{Name: name, Namespace: components.Namespace}, &appsv1.DaemonSet{})
+	return isNotFound("DaemonSet", name, err)
+}
+
+func checkForDeploymentRemoval(name string) error {
+	err := framework.Global.Client.Get(context.Background(), types.NamespacedName{Name: name, Namespace: components.Namespace}, &appsv1.Deployment{})
+	return isNotFound("Deployment", name, err)
+}
+
+func checkForCustomResourceDefinitionRemoval(name string) error {
+	crd := &unstructured.Unstructured{}
+	crd.SetGroupVersionKind(schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1beta1", Kind: "CustomResourceDefinition"})
+	err := framework.Global.Client.Get(context.Background(), types.NamespacedName{Name: name}, crd)
+	return isNotFound("CustomResourceDefinition", name, err)
+}
+
 func isNotFound(componentType string, componentName string, clientGetOutput error) error {
 	if clientGetOutput != nil {
 		if apierrors.IsNotFound(clientGetOutput) {
@@ -7,6 +7,7 @@ type Component struct {
 	SecurityContextConstraints string
 	DaemonSets                 []string
 	Deployments                []string
+	CustomResourceDefinitions  []string
 }
 
 var (
@@ -31,7 +32,8 @@ var (
 		ClusterRole:                "multus",
 		ClusterRoleBinding:         "multus",
 		SecurityContextConstraints: "multus",
-		DaemonSets:                 []string{"kube-multus-ds-amd64"},
+		DaemonSets:                 []string{"kube-multus-ds"},
+		CustomResourceDefinitions:  []string{"network-attachment-definitions.k8s.cni.cncf.io"},
 	}
 	NMStateComponent = Component{
 		ComponentName:              "NMState",
@@ -39,6 +41,10 @@ var (
 		ClusterRole:                "nmstate-handler",
 		SecurityContextConstraints: "nmstate",
 		DaemonSets:                 []string{"nmstate-handler"},
+		CustomResourceDefinitions: []string{
+			"nodenetworkstates.nmstate.io",
+			"nodenetworkconfigurationpolicies.nmstate.io",
+		},
 	}
 	OvsComponent = Component{
 		ComponentName:              "Ovs",
@@ -46,7 +52,7 @@ var (
 		ClusterRoleBinding:         "ovs-cni-marker-crb",
 		SecurityContextConstraints: "ovs-cni-marker",
 		DaemonSets: []string{
-			"ovs-cni-amd64",
+			"ovs-cni",
 		},
 	}
 	AllComponents = []Component{
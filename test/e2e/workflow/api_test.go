@@ -0,0 +1,43 @@
+package test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	framework "github.com/operator-framework/operator-sdk/pkg/test"
+	"k8s.io/apimachinery/pkg/types"
+
+	opv1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1"
+	opv1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/names"
+
+	. "github.com/kubevirt/cluster-network-addons-operator/test/operations"
+)
+
+var _ = Describe("NetworkAddonsConfig", func() {
+	Context("when a config is created using v1alpha1 API", func() {
+		BeforeEach(func() {
+			configSpec := opv1alpha1.NetworkAddonsConfigSpec{
+				LinuxBridge: &opv1alpha1.LinuxBridge{},
+				KubeMacPool: &opv1alpha1.KubeMacPool{
+					RangeStart: "02:00:00:00:00:00",
+					RangeEnd:   "02:0F:FF:FF:FF:FF",
+				},
+			}
+			CreateConfig(configSpec)
+		})
+
+		It("should be available using v1 API with the same Spec", func() {
+			config := &opv1.NetworkAddonsConfig{}
+			err := framework.Global.Client.Get(context.TODO(), types.NamespacedName{Name: names.OPERATOR_CONFIG}, config)
+			Expect(err).NotTo(HaveOccurred(), "Failed to fetch Config using v1 API")
+
+			Expect(config.Spec.LinuxBridge).NotTo(BeNil())
+			Expect(config.Spec.KubeMacPool).To(Equal(&opv1.KubeMacPool{
+				RangeStart: "02:00:00:00:00:00",
+				RangeEnd:   "02:0F:FF:FF:FF:FF",
+			}))
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 
 	opv1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
 	. "github.com/kubevirt/cluster-network-addons-operator/test/check"
@@ -146,6 +147,26 @@ var _ = Describe("NetworkAddonsConfig", func() {
 			DeleteConfig()
 			CheckComponentsRemoval(components)
 		})
+		It("should be able to remove a single component and keep the others running", func() {
+			configSpecWithoutOvs := configSpec
+			configSpecWithoutOvs.Ovs = nil
+			UpdateConfig(configSpecWithoutOvs)
+			CheckComponentsRemoval([]Component{OvsComponent})
+			CheckConfigCondition(ConditionAvailable, ConditionTrue, 15*time.Minute, CheckDoNotRepeat)
+			CheckComponentsDeployment([]Component{MultusComponent, LinuxBridgeComponent, NMStateComponent, KubeMacPoolComponent})
+		})
+
+		It("should be able to deploy a removed component again", func() {
+			configSpecWithoutKubeMacPool := configSpec
+			configSpecWithoutKubeMacPool.KubeMacPool = nil
+			UpdateConfig(configSpecWithoutKubeMacPool)
+			CheckComponentsRemoval([]Component{KubeMacPoolComponent})
+			CheckConfigCondition(ConditionAvailable, ConditionTrue, 15*time.Minute, CheckDoNotRepeat)
+
+			UpdateConfig(configSpec)
+			CheckConfigCondition(ConditionAvailable, ConditionTrue, 15*time.Minute, CheckDoNotRepeat)
+			CheckComponentsDeployment(components)
+		})
 		//2300
 		It("should be able to remove the config and create it again", func() {
 			DeleteConfig()
@@ -162,6 +183,14 @@ var _ = Describe("NetworkAddonsConfig", func() {
 			CheckConfigCondition(ConditionAvailable, ConditionTrue, 15*time.Minute, CheckDoNotRepeat)
 		})
 
+		It("should store the generated MAC range and defaults in the config", func() {
+			rangeStart, rangeEnd := CheckUnicastAndValidity()
+			config := GetConfig()
+			Expect(config.Spec.KubeMacPool.RangeStart).To(Equal(rangeStart))
+			Expect(config.Spec.KubeMacPool.RangeEnd).To(Equal(rangeEnd))
+			Expect(config.Spec.ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+		})
+
 		It("should modify the MAC range after being redeployed ", func() {
 			oldRangeStart, oldRangeEnd := CheckUnicastAndValidity()
 			By("Redeploying KubeMacPool")
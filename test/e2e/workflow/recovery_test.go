@@ -36,4 +36,20 @@ var _ = Describe("NetworkAddonsConfig", func() {
 			})
 		})
 	})
+
+	Context("when objects of a deployed component are removed", func() {
+		BeforeEach(func() {
+			configSpec := opv1alpha1.NetworkAddonsConfigSpec{
+				LinuxBridge: &opv1alpha1.LinuxBridge{},
+			}
+			CreateConfig(configSpec)
+			CheckConfigCondition(ConditionAvailable, ConditionTrue, 15*time.Minute, CheckDoNotRepeat)
+			RemoveComponentObjects(LinuxBridgeComponent)
+		})
+
+		It("should restore them", func() {
+			CheckComponentsRestoration([]Component{LinuxBridgeComponent})
+			CheckConfigCondition(ConditionAvailable, ConditionTrue, 15*time.Minute, CheckDoNotRepeat)
+		})
+	})
 })
@@ -15,7 +15,7 @@ import (
 var _ = Describe("NetworkAddonsConfig", func() {
 	Context("when there is no running config", func() {
 		Context("and an invalid config is created", func() {
-			BeforeEach(func() {
+			It("should be rejected", func() {
 				configSpec := opv1alpha1.NetworkAddonsConfigSpec{
 					ImagePullPolicy: v1.PullAlways,
 					KubeMacPool: &opv1alpha1.KubeMacPool{
@@ -26,12 +26,7 @@ var _ = Describe("NetworkAddonsConfig", func() {
 					Ovs:         &opv1alpha1.Ovs{},
 					NMState:     &opv1alpha1.NMState{},
 				}
-				CreateConfig(configSpec)
-			})
-
-			It("should report Failing condition and Available must be set to False", func() {
-				CheckConfigCondition(ConditionDegraded, ConditionTrue, time.Minute, CheckDoNotRepeat)
-				CheckConfigCondition(ConditionAvailable, ConditionFalse, CheckImmediately, CheckDoNotRepeat)
+				CheckConfigCreationRejected(configSpec)
 			})
 		})
 	})
@@ -39,21 +34,25 @@ var _ = Describe("NetworkAddonsConfig", func() {
 	Context("when a valid config is deployed", func() {
 		BeforeEach(func() {
 			configSpec := opv1alpha1.NetworkAddonsConfigSpec{
-				LinuxBridge: &opv1alpha1.LinuxBridge{},
+				KubeMacPool: &opv1alpha1.KubeMacPool{
+					RangeStart: "02:00:00:00:00:00",
+					RangeEnd:   "02:00:00:00:FF:FF",
+				},
 			}
 			CreateConfig(configSpec)
-			CheckConfigCondition(ConditionAvailable, ConditionTrue, time.Minute, CheckDoNotRepeat)
+			CheckConfigCondition(ConditionAvailable, ConditionTrue, 15*time.Minute, CheckDoNotRepeat)
 		})
 
-		Context("and a component which does not support removal is removed from the Spec", func() {
-			BeforeEach(func() {
-				configSpec := opv1alpha1.NetworkAddonsConfigSpec{}
-				UpdateConfig(configSpec)
-			})
-
-			It("should report Failing condition and remain stop being Available", func() {
-				CheckConfigCondition(ConditionDegraded, ConditionTrue, time.Minute, CheckDoNotRepeat)
-				CheckConfigCondition(ConditionAvailable, ConditionFalse, CheckImmediately, CheckDoNotRepeat)
+		Context("and configuration of a component which does not support modification is changed", func() {
+			It("should be rejected by the admission webhook and the config should remain Available", func() {
+				configSpec := opv1alpha1.NetworkAddonsConfigSpec{
+					KubeMacPool: &opv1alpha1.KubeMacPool{
+						RangeStart: "02:00:00:00:00:00",
+						RangeEnd:   "02:00:00:FF:FF:FF",
+					},
+				}
+				CheckConfigUpdateRejected(configSpec)
+				CheckConfigCondition(ConditionAvailable, ConditionTrue, CheckImmediately, CheckDoNotRepeat)
 			})
 		})
 	})
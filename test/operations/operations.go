@@ -4,17 +4,23 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	framework "github.com/operator-framework/operator-sdk/pkg/test"
 	"gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
 	opv1alpha1 "github.com/kubevirt/cluster-network-addons-operator/pkg/apis/networkaddonsoperator/v1alpha1"
+	"github.com/kubevirt/cluster-network-addons-operator/pkg/components"
 	"github.com/kubevirt/cluster-network-addons-operator/pkg/names"
+	. "github.com/kubevirt/cluster-network-addons-operator/test/check"
 )
 
 func GetConfig() *opv1alpha1.NetworkAddonsConfig {
@@ -57,6 +63,34 @@ func UpdateConfig(configSpec opv1alpha1.NetworkAddonsConfigSpec) {
 	Expect(err).NotTo(HaveOccurred(), "Failed to update the Config")
 }
 
+// CheckConfigCreationRejected makes sure that the config is refused by the CRD schema or the admission webhook
+func CheckConfigCreationRejected(configSpec opv1alpha1.NetworkAddonsConfigSpec) {
+	By(fmt.Sprintf("Applying invalid NetworkAddonsConfig:\n%s", configSpecToYaml(configSpec)))
+
+	config := &opv1alpha1.NetworkAddonsConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: names.OPERATOR_CONFIG,
+		},
+		Spec: configSpec,
+	}
+
+	err := framework.Global.Client.Create(context.TODO(), config, &framework.CleanupOptions{})
+	Expect(err).To(HaveOccurred(), "Invalid Config was not rejected")
+	Expect(apierrors.IsForbidden(err) || apierrors.IsInvalid(err)).To(BeTrue(), "Config was rejected for an unexpected reason: %v", err)
+}
+
+// CheckConfigUpdateRejected makes sure that the update of the config is refused by the admission webhook
+func CheckConfigUpdateRejected(configSpec opv1alpha1.NetworkAddonsConfigSpec) {
+	By(fmt.Sprintf("Updating NetworkAddonsConfig with unsafe change:\n%s", configSpecToYaml(configSpec)))
+
+	config := GetConfig()
+
+	config.Spec = configSpec
+	err := framework.Global.Client.Update(context.TODO(), config)
+	Expect(err).To(HaveOccurred(), "Unsafe change of the Config was not rejected")
+	Expect(apierrors.IsForbidden(err)).To(BeTrue(), "Config update was rejected for an unexpected reason: %v", err)
+}
+
 func DeleteConfig() {
 	By("Removing NetworkAddonsConfig")
 
@@ -68,6 +102,41 @@ func DeleteConfig() {
 
 	err := framework.Global.Client.Delete(context.TODO(), config)
 	Expect(err).NotTo(HaveOccurred(), "Failed to remove the Config")
+
+	// The config is kept by its finalizer until all its components are torn down
+	By("Waiting until components of NetworkAddonsConfig are torn down")
+	Eventually(func() error {
+		err := framework.Global.Client.Get(context.TODO(), types.NamespacedName{Name: names.OPERATOR_CONFIG}, config)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("config is still being torn down, current conditions: %v", config.Status.Conditions)
+	}, 15*time.Minute, time.Second).ShouldNot(HaveOccurred(), "Config was not removed within the given timeout")
+}
+
+// RemoveComponentObjects removes the ClusterRole and workloads of a deployed component, as if
+// somebody removed them by accident
+func RemoveComponentObjects(component Component) {
+	By(fmt.Sprintf("Removing objects of component %s", component.ComponentName))
+
+	objs := []runtime.Object{}
+	if component.ClusterRole != "" {
+		objs = append(objs, &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: component.ClusterRole}})
+	}
+	for _, name := range component.DaemonSets {
+		objs = append(objs, &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: components.Namespace}})
+	}
+	for _, name := range component.Deployments {
+		objs = append(objs, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: components.Namespace}})
+	}
+
+	for _, obj := range objs {
+		err := framework.Global.Client.Delete(context.TODO(), obj)
+		Expect(err).NotTo(HaveOccurred(), "Failed to remove object of the component")
+	}
 }
 
 // Convert NetworkAddonsConfig specification to a yaml format we would expect in a manifest
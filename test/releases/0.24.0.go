@@ -9,7 +9,7 @@ func init() {
 		Version: "0.24.0",
 		Containers: []opv1alpha1.Container{
 			opv1alpha1.Container{
-				ParentName: "kube-multus-ds-amd64",
+				ParentName: "kube-multus-ds",
 				ParentKind: "DaemonSet",
 				Name:       "kube-multus",
 				Image:      "quay.io/kubevirt/cluster-network-addon-multus:v3.2.0-1.gitbf61002",
@@ -39,13 +39,13 @@ func init() {
 				Image:      "quay.io/nmstate/kubernetes-nmstate-handler:v0.12.0",
 			},
 			opv1alpha1.Container{
-				ParentName: "ovs-cni-amd64",
+				ParentName: "ovs-cni",
 				ParentKind: "DaemonSet",
 				Name:       "ovs-cni-plugin",
 				Image:      "quay.io/kubevirt/ovs-cni-plugin:v0.9.0",
 			},
 			opv1alpha1.Container{
-				ParentName: "ovs-cni-amd64",
+				ParentName: "ovs-cni",
 				ParentKind: "DaemonSet",
 				Name:       "ovs-cni-marker",
 				Image:      "quay.io/kubevirt/ovs-cni-marker:v0.9.0",
@@ -0,0 +1,104 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2018 Red Hat, Inc.
+ *
+ */
+
+// data-embedder generates a Go source file holding all manifest templates
+// from the data directory, so they can be compiled into the operator binary.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+func main() {
+	dataDir := flag.String("data-dir", "./data", "Directory with templates of operand manifests")
+	output := flag.String("output", "./pkg/render/zz_generated.manifests.go", "Path of the generated Go file")
+	pkg := flag.String("package", "render", "Package of the generated Go file")
+	flag.Parse()
+
+	files, err := readFiles(*dataDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read manifests: %v\n", err)
+		os.Exit(1)
+	}
+
+	source, err := generate(*pkg, files)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to generate embedded manifests: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := ioutil.WriteFile(*output, source, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+}
+
+// readFiles returns content of all files in the given directory, keyed by
+// their slash separated path relative to the directory
+func readFiles(dir string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relPath)] = content
+		return nil
+	})
+	return files, err
+}
+
+func generate(pkg string, files map[string][]byte) ([]byte, error) {
+	paths := []string{}
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	buffer := bytes.Buffer{}
+	fmt.Fprintf(&buffer, "// Code generated by data-embedder. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buffer, "package %s\n\n", pkg)
+	fmt.Fprintf(&buffer, "// embeddedManifests holds content of the data directory, keyed by path relative to it\n")
+	fmt.Fprintf(&buffer, "var embeddedManifests = map[string]string{\n")
+	for _, path := range paths {
+		fmt.Fprintf(&buffer, "%q: %q,\n", path, files[path])
+	}
+	fmt.Fprintf(&buffer, "}\n")
+
+	return format.Source(buffer.Bytes())
+}
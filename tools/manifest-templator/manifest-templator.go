@@ -25,9 +25,12 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"log"
 	"os"
+	"sort"
 	"strings"
 	"text/template"
+	"unicode"
 
 	"github.com/ghodss/yaml"
 	"github.com/spf13/pflag"
@@ -47,6 +50,8 @@ type operatorData struct {
 	CRD               *extv1beta1.CustomResourceDefinition
 	CRDString         string
 	CRString          string
+	SpecDescriptors   string
+	StatusDescriptors string
 }
 
 type templateData struct {
@@ -134,6 +139,71 @@ func marshallObject(obj interface{}, writer io.Writer) error {
 	return nil
 }
 
+// getDescriptors lists top-level properties of the schema together with their descriptions
+// in the format of CSV spec and status descriptors
+func getDescriptors(schema extv1beta1.JSONSchemaProps, indention int) string {
+	properties := []string{}
+	for property := range schema.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	writer := strings.Builder{}
+	for _, property := range properties {
+		descriptor := map[string]string{
+			"path":        property,
+			"displayName": displayName(property),
+			"description": schema.Properties[property].Description,
+		}
+		err := marshallObject(descriptor, &writer)
+		check(err)
+	}
+
+	out := strings.Builder{}
+	spaces := strings.Repeat(" ", indention)
+	scanner := bufio.NewScanner(strings.NewReader(writer.String()))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "---") {
+			continue
+		}
+		// each descriptor starts with "description", the first of sorted keys
+		if strings.HasPrefix(line, "description") {
+			out.WriteString(spaces + "- " + line + "\n")
+		} else {
+			out.WriteString(spaces + "  " + line + "\n")
+		}
+	}
+	return out.String()
+}
+
+// displayName converts camelCase property name to space separated words, e.g. "Image Pull Policy"
+func displayName(property string) string {
+	out := strings.Builder{}
+	for i, r := range property {
+		if i == 0 {
+			out.WriteRune(unicode.ToUpper(r))
+			continue
+		}
+		if unicode.IsUpper(r) && !unicode.IsUpper(rune(property[i-1])) {
+			out.WriteRune(' ')
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+// splitList splits comma separated list passed as a flag, empty items are dropped
+func splitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func getCNA(data *templateData) {
 	writer := strings.Builder{}
 
@@ -190,10 +260,22 @@ func getCNA(data *templateData) {
 
 	// Get CNA CRD
 	writer = strings.Builder{}
-	crd := components.GetCrd()
-	marshallObject(crd, &writer)
+	crd, err := components.GetCrd()
+	if err != nil {
+		log.Fatalf("failed to generate CRD: %v", err)
+	}
+	crdObject, err := components.UnstructuredCrd(crd)
+	if err != nil {
+		log.Fatalf("failed to convert CRD: %v", err)
+	}
+	marshallObject(crdObject.Object, &writer)
 	crdString := writer.String()
 
+	// Get descriptors of CNA CRD fields for CSV
+	schema := crd.Spec.Validation.OpenAPIV3Schema
+	specDescriptors := getDescriptors(schema.Properties["spec"], 8)
+	statusDescriptors := getDescriptors(schema.Properties["status"], 8)
+
 	// Get CNA CR
 	writer = strings.Builder{}
 	cr := components.GetCR()
@@ -210,6 +292,8 @@ func getCNA(data *templateData) {
 		CRD:               crd,
 		CRDString:         crdString,
 		CRString:          crString,
+		SpecDescriptors:   specDescriptors,
+		StatusDescriptors: statusDescriptors,
 	}
 	data.CNA = &cnaData
 }
@@ -230,6 +314,14 @@ func main() {
 	nmStateHandlerImage := flag.String("nm-state-handler-image", components.NMStateHandlerImageDefault, "The nmstate handler image managed by CNA")
 	ovsCniImage := flag.String("ovs-cni-image", components.OvsCniImageDefault, "The ovs cni image managed by CNA")
 	ovsMarkerImage := flag.String("ovs-marker-image", components.OvsMarkerImageDefault, "The ovs marker image managed by CNA")
+	architecturesDefault := strings.Join(components.ArchitecturesDefault, ",")
+	multusArchitectures := flag.String("multus-image-architectures", architecturesDefault, "Comma separated list of architectures supported by the multus image")
+	linuxBridgeCniArchitectures := flag.String("linux-bridge-cni-image-architectures", architecturesDefault, "Comma separated list of architectures supported by the linux bridge cni image")
+	linuxBridgeMarkerArchitectures := flag.String("linux-bridge-marker-image-architectures", architecturesDefault, "Comma separated list of architectures supported by the linux bridge marker image")
+	kubeMacPoolArchitectures := flag.String("kubemacpool-image-architectures", architecturesDefault, "Comma separated list of architectures supported by the kubemacpool image")
+	nmStateHandlerArchitectures := flag.String("nm-state-handler-image-architectures", architecturesDefault, "Comma separated list of architectures supported by the nmstate handler image")
+	ovsCniArchitectures := flag.String("ovs-cni-image-architectures", architecturesDefault, "Comma separated list of architectures supported by the ovs cni image")
+	ovsMarkerArchitectures := flag.String("ovs-marker-image-architectures", architecturesDefault, "Comma separated list of architectures supported by the ovs marker image")
 	dumpOperatorCRD := flag.Bool("dump-crds", false, "Append operator CRD to bottom of template. Used for csv-generator")
 	inputFile := flag.String("input-file", "", "Not used for csv-generator")
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
@@ -253,6 +345,14 @@ func main() {
 			NMStateHandler:    *nmStateHandlerImage,
 			OvsCni:            *ovsCniImage,
 			OvsMarker:         *ovsMarkerImage,
+
+			MultusArchitectures:            splitList(*multusArchitectures),
+			LinuxBridgeCniArchitectures:    splitList(*linuxBridgeCniArchitectures),
+			LinuxBridgeMarkerArchitectures: splitList(*linuxBridgeMarkerArchitectures),
+			KubeMacPoolArchitectures:       splitList(*kubeMacPoolArchitectures),
+			NMStateHandlerArchitectures:    splitList(*nmStateHandlerArchitectures),
+			OvsCniArchitectures:            splitList(*ovsCniArchitectures),
+			OvsMarkerArchitectures:         splitList(*ovsMarkerArchitectures),
 		}).FillDefaults(),
 	}
 